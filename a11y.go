@@ -0,0 +1,74 @@
+package menubar
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AnnouncementMsg carries a plain-text description of the menu focus, e.g.
+// "File menu, Save, 3 of 5", for forwarding to speech or braille output or
+// rendering in a status line. Only sent when Model.Accessible is set.
+type AnnouncementMsg struct {
+	MenuID string
+	Text   string
+}
+
+func (msg AnnouncementMsg) menuID() string { return msg.MenuID }
+
+func (m Model) announce(text string) tea.Cmd {
+	return func() tea.Msg { return AnnouncementMsg{MenuID: m.ID, Text: text} }
+}
+
+// announcement describes the currently focused item.
+func (m Model) announcement() string {
+	if !m.Active {
+		return "Menu bar inactive"
+	}
+
+	menu := &m
+	context := "Menu bar"
+	for menu.hasOpenSubmenu() {
+		context = stripANSI(m.tr(menu.Items[menu.OpenSubMenu].Label)) + " menu"
+		menu = menu.SubMenuState
+	}
+	if menu.Selection < 0 || menu.Selection >= len(menu.Items) {
+		return context
+	}
+
+	position, total := 0, 0
+	for i, item := range menu.Items {
+		if item.IsSeparator {
+			continue
+		}
+		total++
+		if i == menu.Selection {
+			position = total
+		}
+	}
+
+	item := menu.Items[menu.Selection]
+	parts := []string{context, stripANSI(m.tr(item.label()))}
+	if item.Modified {
+		parts = append(parts, "modified")
+	}
+	if item.Value != "" {
+		parts = append(parts, item.Value)
+	}
+	if item.Mixed {
+		parts = append(parts, "partially checked")
+	} else if item.Checked {
+		parts = append(parts, "checked")
+	} else if item.Checkable || item.RadioGroup != "" {
+		parts = append(parts, "not checked")
+	}
+	if item.hasSubMenu() {
+		parts = append(parts, "submenu")
+	}
+	if item.Shortcut != "" {
+		parts = append(parts, item.Shortcut)
+	}
+	parts = append(parts, fmt.Sprintf("%d of %d", position, total))
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,42 @@
+package menubar
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SetBusy shows or hides a spinner on the right side of the bar, for ongoing
+// background work. Return the command from Update to start the spinner.
+func (m *Model) SetBusy(busy bool) tea.Cmd {
+	m.touch()
+	if busy == m.busy {
+		return nil
+	}
+	m.busy = busy
+	if !busy {
+		return nil
+	}
+	m.spinner = spinner.New(spinner.WithSpinner(spinner.MiniDot))
+	return m.spinner.Tick
+}
+
+// Busy reports whether the spinner shown with SetBusy is running.
+func (m Model) Busy() bool {
+	return m.busy
+}
+
+func (m *Model) updateSpinner(msg spinner.TickMsg) tea.Cmd {
+	if !m.busy || msg.ID != m.spinner.ID() {
+		return nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return cmd
+}
+
+func (m Model) spinnerView() string {
+	if !m.busy {
+		return ""
+	}
+	return m.spinner.View()
+}
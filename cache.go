@@ -0,0 +1,174 @@
+package menubar
+
+import (
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io"
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxCacheEntries = 64
+
+// renderCache holds rendered bar and dropdown strings keyed on a fingerprint of
+// everything that affects their output, so unchanged frames skip lipgloss.
+type renderCache struct {
+	entries map[uint64]string
+	widths  map[uint64][]int // Bar item widths and dropdown row heights
+
+	gen uint64 // Last generation handed out, see next
+
+	derived    *derivedStyles
+	derivedSum uint64 // stylesSum of the styles derived was computed from
+
+	barRightWidth, barWidth int // Size of the bar as last rendered, for hit testing
+}
+
+func newRenderCache() *renderCache {
+	return &renderCache{entries: make(map[uint64]string), widths: make(map[uint64][]int)}
+}
+
+// next returns a generation number no model sharing the cache has used yet.
+// Models key their renders on the generation of their items, so a new one
+// makes them render afresh.
+func (c *renderCache) next() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.gen++
+	return c.gen
+}
+
+// clear drops every render, for when items shared between models changed.
+func (c *renderCache) clear() {
+	if c != nil {
+		c.entries = make(map[uint64]string)
+		c.widths = make(map[uint64][]int)
+	}
+}
+
+func (c *renderCache) get(key uint64, render func() string) string {
+	if c == nil {
+		return render()
+	}
+	if s, ok := c.entries[key]; ok {
+		return s
+	}
+	if len(c.entries) >= maxCacheEntries {
+		c.entries = make(map[uint64]string)
+	}
+	s := render()
+	c.entries[key] = s
+	return s
+}
+
+func (c *renderCache) getWidths(key uint64) ([]int, bool) {
+	if c == nil {
+		return nil, false
+	}
+	widths, ok := c.widths[key]
+	return widths, ok
+}
+
+func (c *renderCache) setWidths(key uint64, widths []int) {
+	if c == nil {
+		return
+	}
+	if len(c.widths) >= maxCacheEntries {
+		c.widths = make(map[uint64][]int)
+	}
+	c.widths[key] = widths
+}
+
+func (c *renderCache) setBarSize(rightWidth, width int) {
+	if c != nil {
+		c.barRightWidth, c.barWidth = rightWidth, width
+	}
+}
+
+func (c *renderCache) barSize() (int, int) {
+	if c == nil {
+		return 0, 0
+	}
+	return c.barRightWidth, c.barWidth
+}
+
+func (m Model) fingerprint(kind string, extra ...any) uint64 {
+	h := fnv.New64a()
+	fmt.Fprint(h, kind, m.Active, m.Selection, m.OpenSubMenu, m.scroll, m.visibleRows(), m.failed, m.blurred, m.Glyphs, extra)
+	fmt.Fprint(h, m.ShortcutGap, m.ShortcutAlign, m.HideShortcuts, m.ShowDescriptions, m.minWidth, m.width, m.Justify, m.UniformWidth, m.flashing, m.flashIndex)
+	fmt.Fprint(h, m.itemsGen, m.stylesSum())
+	m.writeItems(h)
+	return h.Sum64()
+}
+
+// styleProbe is rendered with every style by stylesSum. Its lines differ in
+// length so alignment and width limits show.
+const styleProbe = "Wxyz\nw"
+
+// writeItems hashes every item field that affects rendering, as shown: with
+// LabelFunc evaluated and the Localizer's translations. It writes the bytes
+// directly since it runs for every render.
+func (m Model) writeItems(h hash.Hash64) {
+	var flags [1]byte
+	for _, item := range m.Items {
+		for _, s := range []string{m.tr(item.label()), m.tr(item.Description), item.Hotkey, item.Shortcut, item.Value, item.RadioGroup} {
+			io.WriteString(h, s)
+			h.Write([]byte{0})
+		}
+		flags[0] = 0
+		for i, b := range []bool{item.IsSeparator, item.Disabled, item.Checkable, item.Checked, item.Mixed, item.Modified, item.hasSubMenu(), item.Input != nil} {
+			if b {
+				flags[0] |= 1 << i
+			}
+		}
+		h.Write(flags[:])
+		if item.Pinned || item.header {
+			h.Write([]byte{1})
+		}
+		h.Write([]byte{0xff})
+	}
+}
+
+// stylesSum returns a hash of how every style renders, so styles changed in
+// place, or bound to another renderer, render afresh.
+func stylesSum(s Styles) uint64 {
+	h := fnv.New64a()
+	v := reflect.ValueOf(s)
+	for i := 0; i < v.NumField(); i++ {
+		if style, ok := v.Field(i).Interface().(lipgloss.Style); ok {
+			io.WriteString(h, style.Render(styleProbe))
+		} else {
+			fmt.Fprint(h, v.Field(i).Interface())
+		}
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// frame returns m with its styles hashed once for a whole view, instead of
+// for the bar and again for every dropdown rendered with it.
+func (m Model) frame() Model {
+	if m.frameSum == 0 {
+		m.frameSum = stylesSum(m.Styles)
+	}
+	return m
+}
+
+// stylesSum returns the stylesSum of m.Styles, as hashed for the view being
+// rendered if there is one.
+func (m Model) stylesSum() uint64 {
+	if m.frameSum != 0 {
+		return m.frameSum
+	}
+	return stylesSum(m.Styles)
+}
+
+// changedItems drops the renders of the items, which dropdowns share with
+// their parents, after they were changed in place.
+func (m *Model) changedItems() {
+	m.itemsGen = m.cache.next()
+	m.cache.clear()
+}
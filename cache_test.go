@@ -0,0 +1,88 @@
+package menubar
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCacheInPlaceChanges(t *testing.T) {
+	m := New([]MenuItem{
+		{Label: "File", SubMenu: []MenuItem{{Label: "Autosave", Checkable: true, Tags: []string{"auto"}}}},
+		{Label: "Edit", SubMenu: []MenuItem{{Label: "Undo"}}},
+	})
+	m.UsePlain()
+	m.Open(0)
+	m.View()
+
+	m.CheckTag("auto", true)
+	if !strings.Contains(m.View(), m.Glyphs.Check) {
+		t.Errorf("CheckTag didn't show the check mark:\n%s", m.View())
+	}
+
+	m.Items[1].Label = "Change"
+	if !strings.Contains(m.View(), "Change") {
+		t.Errorf("expected the changed label:\n%s", m.View())
+	}
+
+	m.SubMenuState.Items[0].Label, m.SubMenuState.Items[0].Value = "Backup", "hourly"
+	if view := m.View(); !strings.Contains(view, "Backup") || !strings.Contains(view, "hourly") {
+		t.Errorf("expected the changed dropdown item:\n%s", view)
+	}
+
+	m.SubMenuState.Items[0].Disabled = true
+	m.SubMenuState.Styles.Disabled = m.SubMenuState.Styles.Disabled.Copy().PaddingLeft(3)
+	if view := m.View(); !strings.Contains(view, "|  >"+m.Glyphs.Check+" Backup") {
+		t.Errorf("expected the disabled item's style:\n%s", view)
+	}
+
+	m.Styles.Item = m.Styles.Item.Copy().PaddingLeft(4)
+	if !strings.Contains(m.View(), "    Change") {
+		t.Errorf("expected the changed style:\n%s", m.View())
+	}
+}
+
+func TestCacheLabelFunc(t *testing.T) {
+	zoom := 100
+	m := New([]MenuItem{
+		{LabelFunc: func() string { return fmt.Sprintf("%d%%", zoom) }, SubMenu: []MenuItem{
+			{LabelFunc: func() string { return fmt.Sprintf("Zoom: %d%%", zoom) }},
+		}},
+	})
+	m.UsePlain()
+	m.Open(0)
+	if view := m.View(); !strings.Contains(view, " 100% ") || !strings.Contains(view, "Zoom: 100%") {
+		t.Fatalf("expected the labels at 100%%:\n%s", view)
+	}
+	zoom = 120
+	if view := m.View(); !strings.Contains(view, " 120% ") || !strings.Contains(view, "Zoom: 120%") {
+		t.Errorf("expected the labels to follow LabelFunc:\n%s", view)
+	}
+}
+
+func TestCacheDropdownsDontCollide(t *testing.T) {
+	m := New([]MenuItem{
+		{Label: "File", SubMenu: []MenuItem{{Label: "Open"}}},
+		{Label: "Edit", SubMenu: []MenuItem{{Label: "Copy"}}},
+	})
+	m.UsePlain()
+	m.Open(0)
+	if !strings.Contains(m.View(), "Open") {
+		t.Fatalf("expected the File menu:\n%s", m.View())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if view := m.View(); !strings.Contains(view, "Copy") || strings.Contains(view, "Open") {
+		t.Errorf("expected the Edit menu:\n%s", view)
+	}
+}
+
+func TestStylesBeforeFirstRender(t *testing.T) {
+	m := New([]MenuItem{{Label: "File"}})
+	m.Styles.SelectedItem = lipgloss.NewStyle().PaddingLeft(3)
+	if !strings.Contains(m.View(), "   File") {
+		t.Errorf("expected the style set after New to apply:\n%s", m.View())
+	}
+}
@@ -0,0 +1,79 @@
+package menubar
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// actionContexts tracks the contexts of running ActionCtx actions, shared by
+// a menubar and its dropdowns.
+type actionContexts struct {
+	mu      sync.Mutex
+	seq     int
+	running map[string]runningAction // Keyed by item path
+}
+
+type runningAction struct {
+	seq    int
+	cancel context.CancelFunc
+}
+
+func newActionContexts() *actionContexts {
+	return &actionContexts{running: make(map[string]runningAction)}
+}
+
+// start cancels the action already running for key and returns the context
+// for a new one, along with a function to call once it returns.
+func (a *actionContexts) start(key string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if a == nil {
+		return ctx, cancel
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if prev, ok := a.running[key]; ok {
+		prev.cancel()
+	}
+	a.seq++
+	seq := a.seq
+	a.running[key] = runningAction{seq: seq, cancel: cancel}
+	return ctx, func() {
+		cancel()
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if a.running[key].seq == seq {
+			delete(a.running, key)
+		}
+	}
+}
+
+func (a *actionContexts) cancelAll() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for key, r := range a.running {
+		r.cancel()
+		delete(a.running, key)
+	}
+}
+
+// contextAction adapts an ActionCtx, cancelling its context when the item is
+// activated again before it returns.
+func (m Model) contextAction(path []string, action func(ctx context.Context) tea.Msg) func() tea.Msg {
+	contexts, key := m.actions, pathKey(path)
+	return func() tea.Msg {
+		ctx, done := contexts.start(key)
+		defer done()
+		return action(ctx)
+	}
+}
+
+// CancelActions cancels the contexts of every running ActionCtx action. Call
+// it before quitting so long-running actions can stop.
+func (m Model) CancelActions() {
+	m.actions.cancelAll()
+}
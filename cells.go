@@ -0,0 +1,72 @@
+package menubar
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Cell is one terminal cell written by Draw.
+type Cell struct {
+	Content string // The character in the cell, with any combining marks
+	Style   string // SGR escape sequences in effect for the cell, empty for the default style
+	Width   int    // Columns taken, 2 for wide characters whose second column is left alone
+}
+
+// CellBuffer is a grid of terminal cells, like the back buffer of an
+// application's own compositor.
+type CellBuffer interface {
+	SetCell(x, y int, cell Cell)
+}
+
+// Draw writes the layers of ViewLayers into buf, cell by cell, instead of
+// splicing strings with Overlay. Spaces are written too, so each layer covers
+// what's beneath it.
+func (m Model) Draw(buf CellBuffer, right string, width int) {
+	for _, layer := range m.ViewLayers(right, width) {
+		drawCells(buf, layer.Content, layer.X, layer.Y)
+	}
+}
+
+func drawCells(buf CellBuffer, s string, x, y int) {
+	var style strings.Builder
+	var cell Cell
+	col := x
+	flush := func() {
+		if cell.Content != "" {
+			buf.SetCell(col, y, cell)
+			col += cell.Width
+			cell = Cell{}
+		}
+	}
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			if loc := ansiRegex.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+				flush()
+				if seq := s[i : i+loc[1]]; seq == "\x1b[0m" || seq == "\x1b[m" {
+					style.Reset()
+				} else {
+					style.WriteString(seq)
+				}
+				i += loc[1]
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		if r == '\n' {
+			flush()
+			col, y = x, y+1
+			continue
+		}
+		w := lipgloss.Width(string(r))
+		if w == 0 && cell.Content != "" {
+			cell.Content += string(r)
+			continue
+		}
+		flush()
+		cell = Cell{Content: string(r), Style: style.String(), Width: w}
+	}
+	flush()
+}
@@ -0,0 +1,81 @@
+package menubar
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CopiedMsg is sent by CopyAction once the text is on the native clipboard.
+// The menubar also puts it on the terminal's clipboard with OSC 52 once the
+// message reaches its Update, which works over SSH too.
+type CopiedMsg struct {
+	Text string
+	Err  error // The native clipboard couldn't be written
+}
+
+// CutMsg is sent by CutAction like CopiedMsg. Remove the text from wherever it
+// was cut.
+type CutMsg struct {
+	Text string
+	Err  error // The native clipboard couldn't be written
+}
+
+// PasteMsg is sent by PasteAction with the text read from the clipboard.
+type PasteMsg struct {
+	Text string
+	Err  error
+}
+
+// CopyAction returns an Action that copies the text returned by text.
+func CopyAction(text func() string) func() tea.Msg {
+	return func() tea.Msg {
+		s := text()
+		return CopiedMsg{Text: s, Err: clipboard.WriteAll(s)}
+	}
+}
+
+// CutAction returns an Action like CopyAction that sends a CutMsg instead.
+func CutAction(text func() string) func() tea.Msg {
+	return func() tea.Msg {
+		s := text()
+		return CutMsg{Text: s, Err: clipboard.WriteAll(s)}
+	}
+}
+
+// PasteAction returns an Action that reads the system clipboard. Terminals
+// don't answer OSC 52 reads through bubbletea, so this needs a native
+// clipboard, which isn't there over SSH.
+func PasteAction() func() tea.Msg {
+	return func() tea.Msg {
+		s, err := clipboard.ReadAll()
+		return PasteMsg{Text: s, Err: err}
+	}
+}
+
+// ClipboardItems returns Cut, Copy and Paste items for an Edit menu, where
+// selection returns the text to cut or copy.
+func ClipboardItems(selection func() string) []MenuItem {
+	return []MenuItem{
+		{Label: "Cut", Hotkey: "t", Shortcut: "Ctrl+X", Action: CutAction(selection)},
+		{Label: "Copy", Hotkey: "C", Shortcut: "Ctrl+C", Action: CopyAction(selection)},
+		{Label: "Paste", Hotkey: "P", Shortcut: "Ctrl+V", Action: PasteAction()},
+	}
+}
+
+// setTerminalClipboard sets the terminal's clipboard with OSC 52, written to
+// the program's output in one piece so it isn't interleaved with a frame.
+func (m Model) setTerminalClipboard(s string) {
+	seq := osc52.New(s)
+	switch term := os.Getenv("TERM"); {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(term, "screen"):
+		seq = seq.Screen()
+	}
+	_, _ = io.WriteString(m.output(), seq.String())
+}
@@ -0,0 +1,23 @@
+package menubar
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCopiedMsgWritesOSC52ToTheRendererOutput(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+	var out bytes.Buffer
+	m := New([]MenuItem{{Label: "Edit"}})
+	m.SetRenderer(lipgloss.NewRenderer(&out))
+
+	m.Update(CopiedMsg{Text: "hello"})
+	if want := base64.StdEncoding.EncodeToString([]byte("hello")); !strings.Contains(out.String(), want) {
+		t.Errorf("output %q doesn't set the clipboard to hello", out.String())
+	}
+}
@@ -0,0 +1,129 @@
+package menubar
+
+import (
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Clone returns a deep copy of the model: its items, open dropdowns, styles
+// and contexts, so it can be kept as a snapshot for undo or changed for a
+// preview without affecting the original. Callbacks, Widgets and History are
+// shared, and running ActionCtx actions stay with the original.
+func (m Model) Clone() Model {
+	c := m.cloneWith(nil)
+	if m.cache != nil {
+		c.cache = newRenderCache()
+		c.cache.gen = m.cache.gen
+	}
+	if m.actions != nil {
+		c.actions = newActionContexts()
+	}
+	if m.contexts != nil {
+		c.contexts = make(map[string]*menuContext, len(m.contexts))
+		for name, ctx := range m.contexts {
+			items := c.Items
+			if !sameItems(ctx.items, m.Items) {
+				items = cloneItems(ctx.items)
+			}
+			clone := *ctx
+			clone.items = items
+			if ctx.subMenuState != nil {
+				sub := ctx.subMenuState.cloneWith(linkedItems(ctx.subMenuState.Items, ctx.items, items, ctx.openSubMenu))
+				sub.shareCaches(c.cache, c.actions)
+				clone.subMenuState = &sub
+			}
+			c.contexts[name] = &clone
+		}
+	}
+	c.shareCaches(c.cache, c.actions)
+	return c
+}
+
+// cloneWith deep copies the model and its open dropdowns, using items, which
+// must already be a copy, instead of copying the model's items when set.
+func (m Model) cloneWith(items []MenuItem) Model {
+	c := m
+	if items == nil {
+		items = cloneItems(m.Items)
+	}
+	c.Items = items
+	c.Styles = copyStyles(m.Styles)
+	c.Widgets = append([]Widget(nil), m.Widgets...)
+	c.middleware = append([]Middleware(nil), m.middleware...)
+	c.chord = append([]string(nil), m.chord...)
+	c.path = append([]string(nil), m.path...)
+	c.order = append([]int(nil), m.order...)
+	if m.input != nil {
+		input := *m.input
+		c.input = &input
+	}
+	if m.burger != nil {
+		burger := m.burger.cloneWith(nil)
+		c.burger = &burger
+	}
+	if m.SubMenuState != nil {
+		sub := m.SubMenuState.cloneWith(linkedItems(m.SubMenuState.Items, m.Items, c.Items, m.OpenSubMenu))
+		if sub.source != nil {
+			sub.source = linkedItems(sub.source, m.Items, c.Items, m.OpenSubMenu)
+		}
+		c.SubMenuState = &sub
+	}
+	return c
+}
+
+// shareCaches points the model and its open dropdowns at the same cache and
+// action contexts, the way newSubMenu does.
+func (m *Model) shareCaches(cache *renderCache, actions *actionContexts) {
+	m.cache, m.actions = cache, actions
+	if m.burger != nil {
+		m.burger.shareCaches(cache, actions)
+	}
+	if m.SubMenuState != nil {
+		m.SubMenuState.shareCaches(cache, actions)
+	}
+}
+
+// linkedItems returns the copy of items in the cloned parent, if items is
+// the static submenu of the parent's item open, and a fresh copy otherwise.
+// Dropdowns share their items with the parent so changes like toggling
+// persist, and the clone must keep that link.
+func linkedItems(items, parent, cloned []MenuItem, open int) []MenuItem {
+	if open >= 0 && open < len(parent) && sameItems(items, parent[open].SubMenu) {
+		return cloned[open].SubMenu
+	}
+	return cloneItems(items)
+}
+
+func sameItems(a, b []MenuItem) bool {
+	return len(a) > 0 && len(a) == len(b) && &a[0] == &b[0]
+}
+
+func cloneItems(items []MenuItem) []MenuItem {
+	if items == nil {
+		return nil
+	}
+	clone := make([]MenuItem, len(items))
+	for i, item := range items {
+		item.SubMenu = cloneItems(item.SubMenu)
+		item.Tags = append([]string(nil), item.Tags...)
+		item.replays = append([]string(nil), item.replays...)
+		clone[i] = item
+	}
+	return clone
+}
+
+// copyStyles copies every style and slice, since lipgloss styles share their
+// rules between value copies.
+func copyStyles(styles Styles) Styles {
+	v := reflect.ValueOf(&styles).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Slice && !field.IsNil() {
+			field.Set(reflect.AppendSlice(reflect.MakeSlice(field.Type(), 0, field.Len()), field))
+		} else if style, ok := field.Interface().(lipgloss.Style); ok {
+			field.Set(reflect.ValueOf(style.Copy()))
+		}
+	}
+	return styles
+}
@@ -0,0 +1,63 @@
+package menubar
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyFlushMsg ends the window opened by the first key of a burst, see
+// coalesces.
+type keyFlushMsg struct {
+	menuID string
+	seq    int
+}
+
+// coalesces reports whether msg is an up or down key to coalesce: while a
+// dropdown is open and CoalesceKeys is set, the first one is applied right
+// away and opens a window of CoalesceKeys, during which the others only add
+// up to a net movement applied once it ends.
+func (m Model) coalesces(msg tea.KeyMsg) bool {
+	if m.CoalesceKeys <= 0 || !m.hasOpenSubmenu() || m.SubMenuState.editingInput() {
+		return false
+	}
+	key := msg.String()
+	return key == "up" || key == "down"
+}
+
+// startCoalescing opens the window of the first key of a burst, returning the
+// command that ends it.
+func (m *Model) startCoalescing() tea.Cmd {
+	m.coalescing = true
+	m.queueSeq++
+	id, n := m.ID, m.queueSeq
+	return tea.Tick(m.CoalesceKeys, func(time.Time) tea.Msg { return keyFlushMsg{menuID: id, seq: n} })
+}
+
+// holdKey adds a key pressed during the window to the net movement.
+func (m *Model) holdKey(msg tea.KeyMsg) {
+	if msg.String() == "up" {
+		m.pending--
+	} else {
+		m.pending++
+	}
+}
+
+// flushKeys ends the window, applying the net movement of the keys held back
+// during it, unless the dropdown has closed since.
+func (m Model) flushKeys() (Model, tea.Cmd) {
+	n := m.pending
+	m.pending, m.coalescing = 0, false
+	if !m.hasOpenSubmenu() {
+		return m, nil
+	}
+	key := tea.KeyMsg{Type: tea.KeyDown}
+	if n < 0 {
+		key.Type, n = tea.KeyUp, -n
+	}
+	cmds := make([]tea.Cmd, n)
+	for i := range cmds {
+		m, cmds[i] = m.updateBar(key)
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,46 @@
+package menubar
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCoalesceKeys(t *testing.T) {
+	m := New([]MenuItem{{Label: "File", SubMenu: []MenuItem{
+		{Label: "One"}, {Label: "Two"}, {Label: "Three"}, {Label: "Four"}, {Label: "Five"},
+	}}})
+	m.CoalesceKeys = 50 * time.Millisecond
+	m.Open(0)
+	press := func(keys ...tea.KeyType) {
+		for _, k := range keys {
+			m, _ = m.Update(tea.KeyMsg{Type: k})
+		}
+	}
+
+	press(tea.KeyDown)
+	if got := m.SubMenuState.Selection; got != 1 {
+		t.Fatalf("expected the first key to apply right away, selection %d", got)
+	}
+	press(tea.KeyDown, tea.KeyDown, tea.KeyUp, tea.KeyDown)
+	if got := m.SubMenuState.Selection; got != 1 {
+		t.Fatalf("expected the repeats to be held back, selection %d", got)
+	}
+	m, _ = m.Update(keyFlushMsg{menuID: m.ID, seq: m.queueSeq})
+	if got := m.SubMenuState.Selection; got != 3 {
+		t.Errorf("expected the net movement of 2 once the window ended, selection %d", got)
+	}
+
+	press(tea.KeyDown, tea.KeyUp, tea.KeyUp)
+	if got := m.SubMenuState.Selection; got != 4 {
+		t.Fatalf("expected only the first key of the new burst, selection %d", got)
+	}
+	var cmd tea.Cmd
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, msg := range collect(cmd) {
+		if msg, ok := msg.(ActivatedMsg); ok && msg.Path[1] != "Three" {
+			t.Errorf("expected other keys to apply the held movement first, activated %v", msg.Path)
+		}
+	}
+}
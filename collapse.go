@@ -0,0 +1,46 @@
+package menubar
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// collapsesAt reports whether the bar collapses into a menu button when
+// rendered at width.
+func (m Model) collapsesAt(width int) bool {
+	return !m.isDropdown && m.CollapseWidth > 0 && width > 0 && width < m.CollapseWidth
+}
+
+// collapsed reports whether the bar was last rendered collapsed.
+func (m Model) collapsed() bool {
+	_, width := m.cache.barSize()
+	return m.collapsesAt(width)
+}
+
+// burgerGen is the items generation of the collapsed bar, whose single button
+// looks the same whatever the menus are. next never reaches it.
+const burgerGen = ^uint64(0)
+
+// hamburger returns the collapsed bar: a single menu button whose dropdown
+// lists the top-level menus.
+func (m Model) hamburger() Model {
+	h := m
+	h.Items = []MenuItem{{Label: m.Glyphs.Menu, SubMenu: m.Items}}
+	h.Selection = 0
+	h.CollapseWidth = 0
+	h.itemsGen = burgerGen
+	h.burger = nil
+	h.chord = nil
+	h.closeSubMenu()
+	if m.burger != nil {
+		h.OpenSubMenu, h.SubMenuState = 0, m.burger
+	}
+	return h
+}
+
+// updateCollapsed passes msg on to the collapsed bar. Widgets, dialogs and
+// messages for the bar itself were already handled by Update, so only the
+// menus see it.
+func (m Model) updateCollapsed(msg tea.Msg) (Model, tea.Cmd) {
+	h, cmd := m.hamburger().updateTracked(msg)
+	m.Active = h.Active
+	m.burger = h.SubMenuState
+	return m, cmd
+}
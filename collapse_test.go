@@ -0,0 +1,43 @@
+package menubar
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type tickMsg struct{}
+
+// tickWidget asks for another tick on every tick, like ClockWidget.
+type tickWidget struct{}
+
+func (tickWidget) Init() tea.Cmd { return nil }
+func (w tickWidget) Update(msg tea.Msg) (Widget, tea.Cmd) {
+	if _, ok := msg.(tickMsg); ok {
+		return w, func() tea.Msg { return tickMsg{} }
+	}
+	return w, nil
+}
+func (tickWidget) View() string { return "" }
+
+func TestCollapsedTicksOnce(t *testing.T) {
+	m := New([]MenuItem{{Label: "File", SubMenu: []MenuItem{{Label: "Open"}}}})
+	m.Widgets = []Widget{tickWidget{}}
+	m.CollapseWidth = 40
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 20, Height: 10})
+	m.View()
+	if !m.collapsed() {
+		t.Fatal("expected the bar to collapse")
+	}
+
+	m, cmd := m.Update(tickMsg{})
+	ticks := 0
+	for _, msg := range collect(cmd) {
+		if _, ok := msg.(tickMsg); ok {
+			ticks++
+		}
+	}
+	if ticks != 1 {
+		t.Errorf("expected a single tick while collapsed, got %d", ticks)
+	}
+}
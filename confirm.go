@@ -0,0 +1,46 @@
+package menubar
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Rows of the confirmation prompt opened for items with Confirm set.
+const (
+	confirmYes = 2
+	confirmNo  = 3
+)
+
+// openConfirm opens a Yes/No prompt next to item i, as if it were the item's
+// submenu. The item is activated once the prompt is answered with Yes.
+func (m *Model) openConfirm(i int) {
+	item := m.Items[i]
+	m.closeSubMenu()
+	m.OpenSubMenu = i
+	prompt := m.newSubMenu(item, []MenuItem{
+		{Label: item.Confirm, Disabled: true},
+		Separator(),
+		{Label: "Yes", Hotkey: "y"},
+		{Label: "No", Hotkey: "n"},
+	})
+	prompt.Selection = confirmNo
+	prompt.prompt = true
+	m.SubMenuState = &prompt
+}
+
+// answer closes a confirmation prompt, recording whether it was confirmed.
+func (m *Model) answer(i int) {
+	m.confirmed = i == confirmYes
+	m.Active = false
+}
+
+// settleSubMenu closes the submenu if it closed itself, activating the item
+// behind it if it was a confirmation prompt answered with Yes.
+func (m *Model) settleSubMenu() tea.Cmd {
+	if m.SubMenuState == nil || m.SubMenuState.Active {
+		return nil
+	}
+	i, confirmed := m.OpenSubMenu, m.SubMenuState.prompt && m.SubMenuState.confirmed
+	m.closeSubMenu()
+	if confirmed {
+		return m.run(i)
+	}
+	return nil
+}
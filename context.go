@@ -0,0 +1,53 @@
+package menubar
+
+// menuContext is a named set of top-level menus along with the selection it
+// had when the bar last switched away from it.
+type menuContext struct {
+	items        []MenuItem
+	selection    int
+	openSubMenu  int
+	subMenuState *Model
+}
+
+// AddContext registers a named set of top-level menus, for apps whose menus
+// depend on their mode (browse, edit, debug...). Adding the current context
+// replaces the bar's items.
+func (m *Model) AddContext(name string, items []MenuItem) {
+	m.touch()
+	if m.contexts == nil {
+		m.contexts = make(map[string]*menuContext)
+	}
+	if name == m.context {
+		m.Items = items
+		m.changedItems()
+		m.closeSubMenu()
+		m.ensureValidSelection()
+	}
+	m.contexts[name] = &menuContext{items: items, openSubMenu: -1}
+}
+
+// SetContext swaps the bar's menus for those of a context added with
+// AddContext, restoring the selection it had when last shown. The menus the
+// bar started with are available as the context "". Unknown names are ignored.
+func (m *Model) SetContext(name string) {
+	m.touch()
+	next, ok := m.contexts[name]
+	if !ok || name == m.context {
+		return
+	}
+	m.contexts[m.context] = &menuContext{
+		items:        m.Items,
+		selection:    m.Selection,
+		openSubMenu:  m.OpenSubMenu,
+		subMenuState: m.SubMenuState,
+	}
+	m.context = name
+	m.Items, m.Selection, m.OpenSubMenu, m.SubMenuState = next.items, next.selection, next.openSubMenu, next.subMenuState
+	m.changedItems()
+	m.ensureValidSelection()
+}
+
+// Context returns the name of the current context.
+func (m Model) Context() string {
+	return m.context
+}
@@ -0,0 +1,88 @@
+package menubar
+
+// Open focuses the menubar and opens the menu of top-level item i, closing any
+// other. It reports false, changing nothing, when i is out of range, can't be
+// selected or has no menu.
+func (m *Model) Open(i int) bool {
+	if !m.selectable(i) || !m.Items[i].hasSubMenu() {
+		return false
+	}
+	m.touch()
+	m.Active, m.Selection = true, i
+	m.openCurrentSelection()
+	return m.hasOpenSubmenu()
+}
+
+// Close closes every open menu, leaving the bar focused.
+func (m *Model) Close() {
+	m.touch()
+	m.closeSubMenu()
+}
+
+// IsOpen reports whether a menu is open.
+func (m Model) IsOpen() bool {
+	return m.hasOpenSubmenu()
+}
+
+// Activate focuses the menubar so it takes keyboard input.
+func (m *Model) Activate() {
+	m.touch()
+	m.Active = true
+	m.ensureValidSelection()
+}
+
+// Deactivate closes every menu and releases keyboard input.
+func (m *Model) Deactivate() {
+	m.touch()
+	m.Active = false
+	m.closeSubMenu()
+}
+
+// SetSelection selects top-level item i. When a menu is open, the open menu
+// follows the selection, as it does with the arrow keys. It reports false,
+// changing nothing, when i is out of range or can't be selected.
+func (m *Model) SetSelection(i int) bool {
+	if !m.selectable(i) {
+		return false
+	}
+	m.touch()
+	m.Selection = i
+	if m.hasOpenSubmenu() {
+		m.openCurrentSelection()
+	}
+	return true
+}
+
+// SelectedItem returns the selected item of the innermost open menu.
+func (m Model) SelectedItem() (MenuItem, bool) {
+	menu := &m
+	for menu.hasOpenSubmenu() {
+		menu = menu.SubMenuState
+	}
+	if menu.Selection < 0 || menu.Selection >= len(menu.Items) {
+		return MenuItem{}, false
+	}
+	return menu.Items[menu.Selection], true
+}
+
+func (m Model) selectable(i int) bool {
+	return i >= 0 && i < len(m.Items) && !m.Items[i].IsSeparator && !m.Items[i].Disabled
+}
+
+// repairSubMenu makes OpenSubMenu and SubMenuState agree after they were set
+// directly. An OpenSubMenu without a model has its menu built, and anything
+// else out of step is closed.
+func (m *Model) repairSubMenu() {
+	switch {
+	case m.OpenSubMenu == -1 && m.SubMenuState == nil:
+	case m.OpenSubMenu == -1, m.OpenSubMenu >= len(m.Items), m.OpenSubMenu < -1:
+		m.closeSubMenu()
+	case m.SubMenuState == nil:
+		if !m.selectable(m.OpenSubMenu) {
+			m.closeSubMenu()
+			return
+		}
+		m.Selection = m.OpenSubMenu
+		m.openCurrentSelection()
+	}
+}
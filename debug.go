@@ -0,0 +1,93 @@
+package menubar
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Hitbox is the screen area that mouse events resolve to a given item.
+type Hitbox struct {
+	Rect
+	Path []string // Labels from the top-level item down to the item
+}
+
+// Hitboxes returns the hitboxes of the bar items and the visible rows of every
+// open dropdown, in screen coordinates, using the same geometry as mouse
+// handling.
+func (m Model) Hitboxes() []Hitbox {
+	if m.collapsed() {
+		return m.hamburger().Hitboxes()
+	}
+	return m.hitboxes(m.X, m.Y)
+}
+
+// ItemAt returns the path of the item a mouse event at the given screen
+// position would resolve to, for custom gestures like dropping onto a menu.
+func (m Model) ItemAt(x, y int) ([]string, bool) {
+	boxes := m.Hitboxes()
+	for i := len(boxes) - 1; i >= 0; i-- { // Nested dropdowns are on top
+		if boxes[i].Contains(x, y) {
+			return boxes[i].Path, true
+		}
+	}
+	return nil, false
+}
+
+func (m Model) hitboxes(baseX, baseY int) []Hitbox {
+	var boxes []Hitbox
+	if m.isDropdown {
+		width, _ := m.getDropdownDimensions()
+		y := baseY + lipgloss.Height(m.Styles.Dropdown.GetBorderStyle().Top)
+		rows, heights := m.layoutRows()
+		for j, i := range rows {
+			if i >= 0 {
+				boxes = append(boxes, Hitbox{Rect: Rect{X: baseX, Y: y, Width: width, Height: heights[j]}, Path: m.itemPath(m.Items[i])})
+			}
+			y += heights[j]
+		}
+	} else {
+		h := m.BarHeight()
+		for i, x := range m.barLayout(m.cache.barSize()) {
+			w := m.measureItem(i)
+			boxes = append(boxes, Hitbox{Rect: Rect{X: baseX + x, Y: baseY, Width: w, Height: h}, Path: m.itemPath(m.Items[i])})
+		}
+	}
+
+	if m.hasOpenSubmenu() {
+		subX, subY := m.subMenuOrigin(baseX, baseY)
+		boxes = append(boxes, m.SubMenuState.hitboxes(subX, subY)...)
+	}
+	return boxes
+}
+
+// hitboxLayers outlines every hitbox with brackets down its left and right
+// edges, positioned relative to the given origin like the dropdown layers.
+// Each edge is a layer of its own so the items stay visible between them.
+func (m Model) hitboxLayers(originX, originY int) []DropdownLayer {
+	colors := []lipgloss.Color{"#D70000", "#005FD7"}
+	var layers []DropdownLayer
+	for i, box := range m.Hitboxes() {
+		if box.Width < 2 || box.Height <= 0 {
+			continue
+		}
+		style := m.newStyle().Foreground(colors[i%len(colors)])
+		edge := func(bracket string, x int) DropdownLayer {
+			return DropdownLayer{
+				Content: style.Render(strings.TrimSuffix(strings.Repeat(bracket+"\n", box.Height), "\n")),
+				X:       box.X - originX + x,
+				Y:       box.Y - originY,
+			}
+		}
+		layers = append(layers, edge("[", 0), edge("]", box.Width-1))
+	}
+	return layers
+}
@@ -0,0 +1,37 @@
+package menubar
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDebugOutlines(t *testing.T) {
+	m := New([]MenuItem{
+		{Label: "File", SubMenu: []MenuItem{{Label: "Open"}, {Label: "Save"}}},
+		{Label: "Edit", SubMenu: []MenuItem{{Label: "Undo"}}},
+	})
+	styles := PlainStyles()
+	styles.Bar = styles.Bar.Copy().Border(ASCIIBorder, false, false, true)
+	m.SetStyles(styles)
+	m.X, m.Y = 2, 1
+	m.Debug = true
+	m.Open(0)
+
+	screen := strings.Repeat(strings.Repeat(" ", 40)+"\n", 10)
+	for _, layer := range m.ViewLayers("", 30) {
+		screen = Overlay(screen, layer.Content, layer.X, layer.Y)
+	}
+	rows := strings.Split(screen, "\n")
+	for _, label := range []string{"File", "Edit", "Open", "Save"} {
+		if !strings.Contains(screen, label) {
+			t.Errorf("outlines hid %q:\n%s", label, screen)
+		}
+	}
+	for _, box := range m.Hitboxes() {
+		for y := box.Y; y < box.Y+box.Height; y++ {
+			if row := []rune(rows[y]); row[box.X] != '[' || row[box.X+box.Width-1] != ']' {
+				t.Errorf("expected brackets around %v on row %d:\n%s", box.Path, y, screen)
+			}
+		}
+	}
+}
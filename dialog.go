@@ -0,0 +1,104 @@
+package menubar
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openDialogMsg carries the dialog of an activated item to the bar.
+type openDialogMsg struct {
+	menuID string
+	dialog tea.Model
+}
+
+// closeDialogMsg closes the open dialog, see CloseDialog.
+type closeDialogMsg struct {
+	MenuID string
+	result tea.Msg
+}
+
+func (msg closeDialogMsg) menuID() string { return msg.MenuID }
+
+// CloseDialog returns a command that closes the dialog opened by an item's
+// Dialog in the menubar with the given ID, and then sends result if it isn't
+// nil. Dialogs return it once they are done, e.g. as a huh form's SubmitCmd
+// and CancelCmd.
+func CloseDialog(id string, result tea.Msg) tea.Cmd {
+	return func() tea.Msg { return closeDialogMsg{MenuID: id, result: result} }
+}
+
+// DialogOpen reports whether a dialog opened by an item is showing.
+func (m Model) DialogOpen() bool {
+	return m.dialog != nil
+}
+
+// openDialog adapts an item's Dialog into an action that opens it.
+func (m Model) openDialog(dialog func() tea.Model) func() tea.Msg {
+	id := m.ID
+	return func() tea.Msg { return openDialogMsg{menuID: id, dialog: dialog()} }
+}
+
+// updateDialog opens and closes dialogs, and passes messages to the open one.
+// It reports whether the message was for the dialog alone: keys and mouse
+// events are, so the menus stay put until the dialog closes.
+func (m *Model) updateDialog(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.screen = msg
+	case openDialogMsg:
+		if msg.menuID != m.ID {
+			return nil, false
+		}
+		m.Active = false
+		m.closeSubMenu()
+		m.dialog = msg.dialog
+		cmd := m.dialog.Init()
+		if m.screen.Width > 0 {
+			var sized tea.Cmd
+			m.dialog, sized = m.dialog.Update(m.screen)
+			cmd = tea.Batch(cmd, sized)
+		}
+		return cmd, true
+	case closeDialogMsg:
+		if msg.MenuID != m.ID || m.dialog == nil {
+			return nil, false
+		}
+		m.dialog = nil
+		if msg.result == nil {
+			return nil, true
+		}
+		return func() tea.Msg { return msg.result }, true
+	}
+	if m.dialog == nil {
+		return nil, false
+	}
+	var cmd tea.Cmd
+	m.dialog, cmd = m.dialog.Update(msg)
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		return cmd, true
+	}
+	return cmd, false
+}
+
+// dialogLayer returns the open dialog framed like a dropdown and centered on
+// the screen, as last sized by a tea.WindowSizeMsg, or below the bar of the
+// given width until one arrives.
+func (m Model) dialogLayer(width int) DropdownLayer {
+	view := m.Styles.Dropdown.Render(m.dialog.View())
+	x, y := 0, m.Y+m.BarHeight()
+	if m.screen.Width > 0 {
+		width = m.screen.Width
+		y = (m.screen.Height - lipgloss.Height(view)) / 2
+	}
+	if width > 0 {
+		x = (width - lipgloss.Width(view)) / 2
+	}
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	return DropdownLayer{Content: view, X: x, Y: y}
+}
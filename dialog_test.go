@@ -0,0 +1,37 @@
+package menubar
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type stubModel struct{}
+
+func (stubModel) Init() tea.Cmd                         { return nil }
+func (s stubModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return s, nil }
+func (stubModel) View() string                          { return "stub" }
+
+func TestCloseDialogRoutesByID(t *testing.T) {
+	type savedMsg struct{}
+	bars := make([]Model, 2)
+	for i, id := range []string{"a", "b"} {
+		bars[i] = New([]MenuItem{{Label: "Preferences", Dialog: func() tea.Model { return stubModel{} }}})
+		bars[i].ID = id
+		bars[i], _ = bars[i].Update(bars[i].openDialog(func() tea.Model { return stubModel{} })())
+	}
+
+	msg := CloseDialog("a", savedMsg{})()
+	var cmd tea.Cmd
+	bars[0], cmd = bars[0].Update(msg)
+	if bars[0].DialogOpen() {
+		t.Error("expected the dialog of a to close")
+	}
+	if msgs := collect(cmd); len(msgs) != 1 || msgs[0] != (savedMsg{}) {
+		t.Errorf("expected the result to be sent, got %v", msgs)
+	}
+	bars[1], _ = bars[1].Update(msg)
+	if !bars[1].DialogOpen() {
+		t.Error("expected the dialog of b to stay open")
+	}
+}
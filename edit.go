@@ -0,0 +1,148 @@
+package menubar
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// EditOp is an operation of the standard Edit menu.
+type EditOp int
+
+const (
+	EditUndo EditOp = iota
+	EditCut
+	EditCopy
+	EditPaste
+	EditSelectAll
+)
+
+// EditMsg is sent by the items of EditItems. Pass it to Editor.Update.
+type EditMsg struct {
+	Op EditOp
+}
+
+const (
+	editTag = "menubar.edit"
+	undoTag = "menubar.undo"
+)
+
+// EditItems returns a standard Edit menu of Undo, Cut, Copy, Paste and Select
+// All, sending EditMsgs for an Editor to apply.
+func EditItems() []MenuItem {
+	item := func(label, hotkey, shortcut string, op EditOp, tag string) MenuItem {
+		return MenuItem{
+			Label: label, Hotkey: hotkey, Shortcut: shortcut, Tags: []string{tag},
+			Action: func() tea.Msg { return EditMsg{Op: op} },
+		}
+	}
+	return []MenuItem{
+		item("Undo", "U", "Ctrl+Z", EditUndo, undoTag),
+		Separator(),
+		item("Cut", "t", "Ctrl+X", EditCut, editTag),
+		item("Copy", "C", "Ctrl+C", EditCopy, editTag),
+		item("Paste", "P", "Ctrl+V", EditPaste, editTag),
+		Separator(),
+		item("Select All", "A", "Ctrl+A", EditSelectAll, editTag),
+	}
+}
+
+// TextWidget is a text field the Edit menu can operate on, like a
+// *textinput.Model or a *textarea.Model.
+type TextWidget interface {
+	Focused() bool
+	Value() string
+	SetValue(s string)
+	CursorEnd()
+}
+
+// Editor applies the Edit menu to whichever of a program's text widgets is
+// focused. The bubbles widgets have no selection, so Cut and Copy take the
+// whole text and Select All moves the cursor to its end. They have no undo
+// either, so Undo reverts the Edit menu's own changes, as long as the text
+// hasn't been edited since.
+type Editor struct {
+	undo []edit
+}
+
+type edit struct {
+	before, after string
+}
+
+// Update applies an EditMsg, or inserts the text of a PasteMsg, into the
+// focused widget.
+func (e *Editor) Update(msg tea.Msg, widgets ...TextWidget) tea.Cmd {
+	w := focusedWidget(widgets)
+	if w == nil {
+		return nil
+	}
+	switch msg := msg.(type) {
+	case EditMsg:
+		text := w.Value()
+		switch msg.Op {
+		case EditUndo:
+			if n := len(e.undo); n > 0 && e.undo[n-1].after == text {
+				w.SetValue(e.undo[n-1].before)
+				e.undo = e.undo[:n-1]
+			}
+		case EditCut:
+			e.set(w, "")
+			return CutAction(func() string { return text })
+		case EditCopy:
+			return CopyAction(func() string { return text })
+		case EditPaste:
+			return PasteAction()
+		case EditSelectAll:
+			w.CursorEnd()
+		}
+	case PasteMsg:
+		if msg.Err == nil {
+			e.insert(w, msg.Text)
+		}
+	}
+	return nil
+}
+
+// Enable enables the Edit menu's items while one of the widgets is focused,
+// and Undo while there is something to undo.
+func (e *Editor) Enable(m *Model, widgets ...TextWidget) {
+	w := focusedWidget(widgets)
+	m.EnableTag(editTag, w != nil)
+	m.EnableTag(undoTag, w != nil && len(e.undo) > 0 && e.undo[len(e.undo)-1].after == w.Value())
+}
+
+func (e *Editor) set(w TextWidget, text string) {
+	before := w.Value()
+	w.SetValue(text)
+	e.undo = append(e.undo, edit{before: before, after: w.Value()})
+}
+
+// insert adds text at the cursor where the widget exposes one, and at the end
+// otherwise.
+func (e *Editor) insert(w TextWidget, text string) {
+	before := w.Value()
+	switch t := w.(type) {
+	case interface{ InsertString(string) }:
+		t.InsertString(text)
+	case interface {
+		Position() int
+		SetCursor(int)
+	}:
+		value := []rune(before)
+		pos := t.Position()
+		if pos > len(value) {
+			pos = len(value)
+		}
+		w.SetValue(string(value[:pos]) + text + string(value[pos:]))
+		t.SetCursor(pos + len([]rune(text)))
+	default:
+		w.SetValue(before + text)
+		w.CursorEnd()
+	}
+	e.undo = append(e.undo, edit{before: before, after: w.Value()})
+}
+
+func focusedWidget(widgets []TextWidget) TextWidget {
+	for _, w := range widgets {
+		if w != nil && w.Focused() {
+			return w
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package menubar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type EventKind int
+
+const (
+	EventOpen     EventKind = iota // A menu opened
+	EventClose                     // A menu closed, Duration is how long it was open
+	EventSelect                    // The selection moved to Path
+	EventActivate                  // An item's Action ran, Duration is how long it took
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventOpen:
+		return "open"
+	case EventClose:
+		return "close"
+	case EventSelect:
+		return "select"
+	case EventActivate:
+		return "activate"
+	}
+	return "unknown"
+}
+
+// Event describes something that happened in a menubar, for logging and
+// debugging. Activate events are reported from the goroutine running the
+// item's Action, all other events from Update.
+type Event struct {
+	Kind     EventKind
+	MenuID   string
+	Path     []string
+	Time     time.Time
+	Duration time.Duration
+}
+
+func (e Event) String() string {
+	s := fmt.Sprintf("menubar %s %q", e.Kind, strings.Join(e.Path, " > "))
+	if e.MenuID != "" {
+		s = fmt.Sprintf("menubar[%s] %s %q", e.MenuID, e.Kind, strings.Join(e.Path, " > "))
+	}
+	if e.Duration > 0 {
+		s += " in " + e.Duration.String()
+	}
+	return s
+}
+
+// menuSnapshot records the open menus and selection so changes made by an
+// update can be reported.
+type menuSnapshot struct {
+	open     [][]string
+	openedAt []time.Time
+	selected []string
+}
+
+func (m Model) snapshot() menuSnapshot {
+	var s menuSnapshot
+	menu := &m
+	for menu.hasOpenSubmenu() {
+		menu = menu.SubMenuState
+		s.open = append(s.open, menu.path)
+		s.openedAt = append(s.openedAt, menu.openedAt)
+	}
+	if m.Active && menu.Selection >= 0 && menu.Selection < len(menu.Items) {
+		s.selected = menu.itemPath(menu.Items[menu.Selection])
+	}
+	return s
+}
+
+func (m Model) logChanges(before menuSnapshot) {
+	after := m.snapshot()
+	now := time.Now()
+
+	// Menus are nested, so everything past the first difference changed
+	common := 0
+	for common < len(before.open) && common < len(after.open) &&
+		pathKey(before.open[common]) == pathKey(after.open[common]) &&
+		before.openedAt[common].Equal(after.openedAt[common]) {
+		common++
+	}
+	for i := len(before.open) - 1; i >= common; i-- {
+		m.OnEvent(Event{Kind: EventClose, MenuID: m.ID, Path: before.open[i], Time: now, Duration: now.Sub(before.openedAt[i])})
+	}
+	for i := common; i < len(after.open); i++ {
+		m.OnEvent(Event{Kind: EventOpen, MenuID: m.ID, Path: after.open[i], Time: now})
+	}
+	if after.selected != nil && pathKey(after.selected) != pathKey(before.selected) {
+		m.OnEvent(Event{Kind: EventSelect, MenuID: m.ID, Path: after.selected, Time: now})
+	}
+}
+
+// logAction wraps an item's Action to report how long it took.
+func (m Model) logAction(path []string, action func() tea.Msg) tea.Cmd {
+	if m.OnEvent == nil {
+		return action
+	}
+	return func() tea.Msg {
+		start := time.Now()
+		msg := action()
+		m.OnEvent(Event{Kind: EventActivate, MenuID: m.ID, Path: path, Time: start, Duration: time.Since(start)})
+		return msg
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"os"
 
-	"time"
-
 	menubar "github.com/jejacks0n/bubbletea-menubar"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,15 +16,6 @@ type model struct {
 	width    int
 	height   int
 	content  string
-	now      time.Time
-}
-
-type tickMsg time.Time
-
-func tick() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
 }
 
 type actionMsg string
@@ -79,6 +68,8 @@ func initialModel() model {
 
 	m := menubar.New(items)
 	m.Active = false
+	m.Widgets = []menubar.Widget{menubar.ClockWidget("15:04:05")}
+	m.Styles.Right = m.Styles.Right.Copy().Padding(0, 1)
 	// Use rounded borders
 	//   Options include things like: NormalBorder, RoundedBorder, BlockBorder, OuterHalfBlockBorder, InnerHalfBlockBorder, ThickBorder, DoubleBorder
 	//m.Styles.Dropdown = m.Styles.Dropdown.Border(lipgloss.RoundedBorder())
@@ -124,12 +115,11 @@ func initialModel() model {
 	return model{
 		menubar: m,
 		content: styledContent,
-		now:     time.Now(),
 	}
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(m.menubar.Init(), tick())
+	return m.menubar.Init()
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -153,9 +143,6 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case actionMsg:
 		m.content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#FF00FF")).Render(string(msg))
-	case tickMsg:
-		m.now = time.Time(msg)
-		return m, tick()
 	}
 
 	var cmd tea.Cmd
@@ -168,11 +155,8 @@ func (m model) View() string {
 		return ""
 	}
 
-	// Add right-side content (Dynamic Clock)
-	rightSide := lipgloss.NewStyle().Padding(0, 1).Render(m.now.Format("15:04:05"))
-
-	// Render the bar first
-	bar := m.menubar.ViewBarWithRightSide(rightSide, m.width)
+	// Render the bar first, the clock widget fills in the right side
+	bar := m.menubar.ViewBarWithRightSide("", m.width)
 
 	// Combine bar and content
 	fullView := lipgloss.JoinVertical(lipgloss.Top, bar, m.content)
@@ -0,0 +1,33 @@
+package menubar
+
+import (
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ExecFinishedMsg is sent when the program run by an item's Exec exits, unless
+// the item has an OnExit callback.
+type ExecFinishedMsg struct {
+	MenuID string
+	Path   []string
+	Data   any   // Data of the item
+	Err    error // Non-nil if the program couldn't start or exited unsuccessfully
+}
+
+func (msg ExecFinishedMsg) menuID() string { return msg.MenuID }
+
+// execAction runs the item's Exec with tea.ExecProcess, which suspends the
+// program until it exits. Each activation starts a new exec.Cmd.
+func (m Model) execAction(path []string, item MenuItem) func() tea.Msg {
+	id, data := m.ID, item.Data
+	return func() tea.Msg {
+		done := func(err error) tea.Msg {
+			if item.OnExit != nil {
+				return item.OnExit(err)
+			}
+			return ExecFinishedMsg{MenuID: id, Path: path, Data: data, Err: err}
+		}
+		return tea.ExecProcess(exec.Command(item.Exec, item.ExecArgs...), done)()
+	}
+}
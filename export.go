@@ -0,0 +1,102 @@
+package menubar
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	markdownEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`)
+	troffEscaper    = strings.NewReplacer(`\`, `\e`, `"`, `\(dq`)
+)
+
+// ExportMarkdown documents the menu hierarchy as Markdown, with a heading per
+// top-level menu and a nested list of its items, their shortcuts and
+// descriptions. Separators and menus built by SubMenuFunc are left out.
+func (m Model) ExportMarkdown() string {
+	var b strings.Builder
+	for _, item := range m.Items {
+		if item.IsSeparator {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n", markdownEscaper.Replace(m.tr(item.Label)))
+		if item.Description != "" {
+			fmt.Fprintf(&b, "\n%s\n", m.tr(item.Description))
+		}
+		if len(item.SubMenu) > 0 {
+			b.WriteString("\n")
+			m.writeMarkdownItems(&b, item.SubMenu, 0)
+		}
+	}
+	return b.String()
+}
+
+func (m Model) writeMarkdownItems(b *strings.Builder, items []MenuItem, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, item := range items {
+		if item.IsSeparator {
+			continue
+		}
+		fmt.Fprintf(b, "%s- **%s**", indent, markdownEscaper.Replace(m.tr(item.Label)))
+		if item.Shortcut != "" {
+			fmt.Fprintf(b, " `%s`", item.Shortcut)
+		}
+		if item.Description != "" {
+			fmt.Fprintf(b, " — %s", m.tr(item.Description))
+		}
+		b.WriteString("\n")
+		m.writeMarkdownItems(b, item.SubMenu, depth+1)
+	}
+}
+
+// ExportTroff documents the menu hierarchy like ExportMarkdown, as a section
+// of a man page with a subsection per top-level menu.
+func (m Model) ExportTroff() string {
+	var b strings.Builder
+	b.WriteString(".SH MENUS\n")
+	for _, item := range m.Items {
+		if item.IsSeparator {
+			continue
+		}
+		fmt.Fprintf(&b, ".SS \"%s\"\n", troffEscaper.Replace(m.tr(item.Label)))
+		if item.Description != "" {
+			fmt.Fprintf(&b, "%s\n", troffText(m.tr(item.Description)))
+		}
+		m.writeTroffItems(&b, item.SubMenu, nil)
+	}
+	return b.String()
+}
+
+func (m Model) writeTroffItems(b *strings.Builder, items []MenuItem, parent []string) {
+	for _, item := range items {
+		if item.IsSeparator {
+			continue
+		}
+		path := append(append([]string{}, parent...), m.tr(item.Label))
+		label := troffEscaper.Replace(strings.Join(path, " > "))
+		b.WriteString(".TP\n")
+		if item.Shortcut != "" {
+			fmt.Fprintf(b, ".BR \"%s\" \" (%s)\"\n", label, troffEscaper.Replace(item.Shortcut))
+		} else {
+			fmt.Fprintf(b, ".B \"%s\"\n", label)
+		}
+		if item.Description != "" {
+			fmt.Fprintf(b, "%s\n", troffText(m.tr(item.Description)))
+		}
+		m.writeTroffItems(b, item.SubMenu, path)
+	}
+}
+
+// troffText escapes text lines so none of them is read as a request.
+func troffText(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, `\`, `\e`), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
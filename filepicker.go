@@ -0,0 +1,74 @@
+package menubar
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// filePickerHeight is the number of files shown by a picker without a Height.
+const filePickerHeight = 10
+
+// FileSelectedMsg is sent when a file is chosen from the picker of an item
+// with FilePicker, which then closes.
+type FileSelectedMsg struct {
+	MenuID string
+	Path   []string // Labels from the top-level item down to the item with the FilePicker
+	Data   any      // Data of the item with the FilePicker
+	File   string
+}
+
+func (msg FileSelectedMsg) menuID() string { return msg.MenuID }
+
+// openFilePicker opens the file picker of the selected item as its dropdown,
+// reading its directory right away like FromFS does.
+func (m *Model) openFilePicker(item MenuItem) {
+	fp := item.FilePicker()
+	if fp.AutoHeight || fp.Height <= 0 {
+		// AutoHeight would size the picker to the whole window
+		fp.AutoHeight = false
+		if fp.Height <= 0 {
+			fp.Height = filePickerHeight
+		}
+	}
+	fp.KeyMap.Back.SetKeys("h", "backspace", "left") // Esc closes the dropdown instead
+	if cmd := fp.Init(); cmd != nil {
+		fp, _ = fp.Update(cmd())
+	}
+	sub := m.newSubMenu(item, nil)
+	sub.picker = &fp
+	m.OpenSubMenu = m.Selection
+	m.SubMenuState = &sub
+}
+
+// updateFilePicker passes messages to the picker, closing it once a file is
+// chosen or on esc.
+func (m Model) updateFilePicker(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.Type == tea.KeyEsc {
+		m.Active = false
+		return m, nil
+	}
+	fp, cmd := m.picker.Update(msg)
+	m.picker = &fp
+	if ok, file := fp.DidSelectFile(msg); ok {
+		m.Active = false
+		selected := FileSelectedMsg{MenuID: m.ID, Path: m.path, Data: m.data, File: file}
+		return m, tea.Batch(cmd, func() tea.Msg { return selected })
+	}
+	return m, cmd
+}
+
+// renderFilePicker renders the picker at the item's Width or MinWidth, so the
+// dropdown doesn't change size while moving between files.
+func (m Model) renderFilePicker() string {
+	view := strings.TrimSuffix(m.picker.View(), "\n")
+	width := m.width
+	if width <= 0 {
+		width = m.minWidth
+	}
+	if width -= m.Styles.Dropdown.GetHorizontalFrameSize(); width > 0 {
+		view = lipgloss.PlaceHorizontal(width, lipgloss.Left, lipgloss.NewStyle().MaxWidth(width).Render(view))
+	}
+	return m.Styles.Dropdown.Render(view)
+}
@@ -0,0 +1,63 @@
+package menubar
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// activationFlash is how long an item activated by its Keys stays highlighted.
+const activationFlash = 150 * time.Millisecond
+
+// flashDoneMsg ends the highlight started by flashPath.
+type flashDoneMsg struct {
+	menuID string
+	seq    int
+}
+
+// flashPath briefly highlights the item at path if its menu is open, and
+// otherwise the top-level menu it belongs to, confirming which menu handled a
+// shortcut.
+func (m *Model) flashPath(path []int) tea.Cmd {
+	menu, depth := m, 0
+	for depth < len(path)-1 && menu.hasOpenSubmenu() && menu.OpenSubMenu == path[depth] {
+		menu = menu.SubMenuState
+		depth++
+	}
+	if depth < len(path)-1 {
+		menu, depth = m, 0
+	}
+	menu.flashing, menu.flashIndex = true, path[depth]
+
+	m.flashSeq++
+	id, n := m.ID, m.flashSeq
+	return tea.Tick(activationFlash, func(time.Time) tea.Msg { return flashDoneMsg{menuID: id, seq: n} })
+}
+
+func (m *Model) endFlash() {
+	for menu := m; menu != nil; menu = menu.SubMenuState {
+		menu.flashing = false
+	}
+}
+
+// flashed reports whether item i is highlighted by flashPath.
+func (m Model) flashed(i int) bool {
+	return m.flashing && m.flashIndex == i
+}
+
+// flashMessageDoneMsg restores the right side replaced by Flash.
+type flashMessageDoneMsg struct {
+	menuID string
+	seq    int
+}
+
+// Flash replaces the bar's right side with text, styled with Styles.Message,
+// for the duration d. Return the command from Update so the right side is
+// restored afterwards.
+func (m *Model) Flash(text string, d time.Duration) tea.Cmd {
+	m.touch()
+	m.message = text
+	m.messageSeq++
+	id, n := m.ID, m.messageSeq
+	return tea.Tick(d, func(time.Time) tea.Msg { return flashMessageDoneMsg{menuID: id, seq: n} })
+}
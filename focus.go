@@ -0,0 +1,39 @@
+package menubar
+
+import (
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SetTerminalFocus tells the menubar whether the terminal window has focus.
+// While it doesn't, the bar is rendered with Styles.Unfocused, and with
+// CloseOnBlur set the dropdowns are closed when it loses focus.
+//
+// Update calls it for the FocusMsg and BlurMsg of Bubble Tea versions that
+// report focus changes (with tea.WithReportFocus), so it only needs calling
+// directly when focus is tracked some other way.
+func (m *Model) SetTerminalFocus(focused bool) {
+	m.touch()
+	m.blurred = !focused
+	if m.blurred && m.CloseOnBlur {
+		m.closeSubMenu()
+	}
+}
+
+// terminalFocus reports whether msg is a Bubble Tea focus change, and whether
+// the terminal gained focus. The messages are matched by name since the
+// Bubble Tea version this module builds against predates them.
+func terminalFocus(msg tea.Msg) (focused, ok bool) {
+	t := reflect.TypeOf(msg)
+	if t == nil || t.PkgPath() != reflect.TypeOf(tea.KeyMsg{}).PkgPath() {
+		return false, false
+	}
+	switch t.Name() {
+	case "FocusMsg":
+		return true, true
+	case "BlurMsg":
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,78 @@
+package menubar
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type fsOptions struct {
+	maxDepth  int
+	dirsFirst bool
+	less      func(a, b fs.DirEntry) bool
+}
+
+// FSOption configures FromFS.
+type FSOption func(*fsOptions)
+
+// FSMaxDepth limits how many directory levels deep the browser goes. Deeper
+// directories are shown disabled. 0, the default, means no limit.
+func FSMaxDepth(depth int) FSOption {
+	return func(o *fsOptions) { o.maxDepth = depth }
+}
+
+// FSDirsFirst lists directories before files.
+func FSDirsFirst() FSOption {
+	return func(o *fsOptions) { o.dirsFirst = true }
+}
+
+// FSSort orders the entries of each directory, instead of by name.
+func FSSort(less func(a, b fs.DirEntry) bool) FSOption {
+	return func(o *fsOptions) { o.less = less }
+}
+
+// FromFS builds a cascading file browser from the directory root of fsys:
+// directories become submenus, read each time they open, and files become
+// items that send the message returned by onOpen for their path.
+func FromFS(fsys fs.FS, root string, onOpen func(path string) tea.Msg, opts ...FSOption) []MenuItem {
+	var o fsOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return fsItems(fsys, root, onOpen, o, 1)
+}
+
+func fsItems(fsys fs.FS, dir string, onOpen func(path string) tea.Msg, o fsOptions, depth int) []MenuItem {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return []MenuItem{{Label: err.Error(), Disabled: true}}
+	}
+	if len(entries) == 0 {
+		return []MenuItem{{Label: "(empty)", Disabled: true}}
+	}
+	if o.less != nil {
+		sort.SliceStable(entries, func(i, j int) bool { return o.less(entries[i], entries[j]) })
+	}
+	if o.dirsFirst {
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].IsDir() && !entries[j].IsDir() })
+	}
+
+	items := make([]MenuItem, len(entries))
+	for i, entry := range entries {
+		p := path.Join(dir, entry.Name())
+		switch {
+		case !entry.IsDir():
+			items[i] = MenuItem{Label: entry.Name(), Action: func() tea.Msg { return onOpen(p) }}
+		case o.maxDepth > 0 && depth >= o.maxDepth:
+			items[i] = MenuItem{Label: entry.Name(), Disabled: true}
+		default:
+			items[i] = MenuItem{
+				Label:       entry.Name(),
+				SubMenuFunc: func() []MenuItem { return fsItems(fsys, p, onOpen, o, depth+1) },
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,119 @@
+package menubar
+
+import (
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Glyphs are the characters used to draw indicators and separators.
+type Glyphs struct {
+	SubMenu      string // Right of items with a submenu
+	Check        string // Left of checked items
+	Radio        string // Left of the checked item in a radio group
+	Mixed        string // Left of partially checked items
+	Separator    string // Repeated across the dropdown for separator items
+	BarSeparator string // Separator items in the bar
+	Menu         string // The button a collapsed bar shows
+	Modified     string // Right of modified top-level items
+	Ellipsis     string // Ends truncated labels and pending chords
+	ScrollUp     string // Above the items of a dropdown scrolled down
+	ScrollDown   string // Below the items of a dropdown with more further down
+	Selected     string // In the left padding of the selected item, for styles without colors to show it
+}
+
+func DefaultGlyphs() Glyphs {
+	return Glyphs{
+		SubMenu:      ">",
+		Check:        "✓",
+		Radio:        "•",
+		Mixed:        "−",
+		Separator:    "─",
+		BarSeparator: "|",
+		Menu:         "☰",
+		Modified:     "•",
+		Ellipsis:     "…",
+		ScrollUp:     "▲",
+		ScrollDown:   "▼",
+	}
+}
+
+// ASCIIGlyphs returns glyphs that only use plain ASCII characters, for
+// terminals and fonts without Unicode support.
+func ASCIIGlyphs() Glyphs {
+	return Glyphs{
+		SubMenu:      ">",
+		Check:        "x",
+		Radio:        "*",
+		Mixed:        "-",
+		Separator:    "-",
+		BarSeparator: "|",
+		Menu:         "=",
+		Modified:     "*",
+		Ellipsis:     "...",
+		ScrollUp:     "^",
+		ScrollDown:   "v",
+	}
+}
+
+// UseASCII draws the menubar with ASCIIGlyphs and swaps the borders of its
+// styles for ASCIIBorder. New calls it when the terminal or locale can't
+// render Unicode.
+func (m *Model) UseASCII() {
+	m.touch()
+	m.Glyphs = ASCIIGlyphs()
+	m.SetStyles(asciiBorders(m.Styles))
+}
+
+// UsePlain renders the menubar as plain text, with PlainStyles and
+// ASCIIGlyphs, marking the selected item with ">" instead of colors. The
+// output is pure ASCII without escape sequences, for golden file tests and
+// dumb terminals.
+func (m *Model) UsePlain() {
+	m.touch()
+	m.Glyphs = ASCIIGlyphs()
+	m.Glyphs.Selected = ">"
+	m.SetStyles(PlainStyles())
+}
+
+// marked returns the outer style and content of a selected item with
+// Glyphs.Selected drawn in its left padding, or leaves them as they are when
+// the padding is too narrow for it.
+func (m Model) marked(st itemStyles, content string) (lipgloss.Style, string) {
+	width, padding := lipgloss.Width(m.Glyphs.Selected), st.outer.GetPaddingLeft()
+	if width == 0 || width > padding {
+		return st.outer, content
+	}
+	indent := "\n" + st.base.Render(strings.Repeat(" ", width))
+	content = st.base.Render(m.Glyphs.Selected) + strings.ReplaceAll(content, "\n", indent)
+	return st.outer.Copy().PaddingLeft(padding - width), content
+}
+
+// asciiBorders returns styles with every border replaced by ASCIIBorder.
+func asciiBorders(styles Styles) Styles {
+	v := reflect.ValueOf(&styles).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if style, ok := v.Field(i).Interface().(lipgloss.Style); ok && style.GetBorderStyle() != (lipgloss.Border{}) {
+			v.Field(i).Set(reflect.ValueOf(style.BorderStyle(ASCIIBorder)))
+		}
+	}
+	return styles
+}
+
+// unicodeSupported reports whether the terminal is likely to render the
+// default glyphs and borders. Dumb and console terminals, and locales that
+// explicitly aren't UTF-8, such as LANG=C, can't.
+func unicodeSupported() bool {
+	switch term := os.Getenv("TERM"); {
+	case term == "dumb", term == "linux", strings.HasPrefix(term, "vt"):
+		return false
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(os.Getenv(name)); locale != "" {
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+	return true
+}
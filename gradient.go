@@ -0,0 +1,71 @@
+package menubar
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/termenv"
+)
+
+// gradientStops returns the valid colors of Styles.BarGradient.
+func (m Model) gradientStops() []colorful.Color {
+	var stops []colorful.Color
+	for _, c := range m.Styles.BarGradient {
+		if c, ok := termenv.TrueColor.Color(string(c)).(termenv.RGBColor); ok {
+			if c, err := colorful.Hex(string(c)); err == nil {
+				stops = append(stops, c)
+			}
+		}
+	}
+	return stops
+}
+
+// blend returns the color of a gradient through stops at column x of width
+// columns.
+func blend(stops []colorful.Color, x, width int) colorful.Color {
+	if width < 2 {
+		return stops[0]
+	}
+	t := float64(x) / float64(width-1) * float64(len(stops)-1)
+	i := int(t)
+	if i >= len(stops)-1 {
+		return stops[len(stops)-1]
+	}
+	return stops[i].BlendLab(stops[i+1], t-float64(i)).Clamped()
+}
+
+// paintGradient gives every cell of s, which starts at column x of a bar of
+// width columns, the gradient's background color at that cell.
+func (m Model) paintGradient(s string, x, width int) string {
+	stops := m.gradientStops()
+	if len(stops) < 2 {
+		return s
+	}
+	profile := m.colorProfile()
+	start := x
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			if loc := ansiRegex.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+				b.WriteString(s[i : i+loc[1]])
+				i += loc[1]
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		if r == '\n' {
+			b.WriteRune(r)
+			x = start
+			continue
+		}
+		if seq := profile.Color(blend(stops, x, width).Hex()).Sequence(true); seq != "" {
+			b.WriteString(termenv.CSI + seq + "m")
+		}
+		b.WriteRune(r)
+		x += lipgloss.Width(string(r))
+	}
+	return b.String()
+}
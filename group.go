@@ -0,0 +1,55 @@
+package menubar
+
+// groupItems returns items gathered into the groups named by by, each under a
+// header labelled with its name, along with the index in items of each item,
+// -1 for headers. Groups keep the order they first appear in, items with an
+// empty name come first without a header, and separators are left out.
+func groupItems(items []MenuItem, by func(MenuItem) string) ([]MenuItem, []int) {
+	var names []string
+	groups := map[string][]int{}
+	for i, item := range items {
+		if item.IsSeparator {
+			continue
+		}
+		name := by(item)
+		if _, ok := groups[name]; !ok && name != "" {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], i)
+	}
+
+	grouped := make([]MenuItem, 0, len(items)+len(names))
+	order := make([]int, 0, len(items)+len(names))
+	for _, i := range groups[""] {
+		grouped, order = append(grouped, items[i]), append(order, i)
+	}
+	for _, name := range names {
+		grouped, order = append(grouped, MenuItem{Label: name, Disabled: true, header: true}), append(order, -1)
+		for _, i := range groups[name] {
+			grouped, order = append(grouped, items[i]), append(order, i)
+		}
+	}
+	return grouped, order
+}
+
+// arrangeItems applies the Sort and GroupBy of item to the items of its
+// submenu, returning them with the index in items of each one.
+func arrangeItems(item MenuItem, items []MenuItem) ([]MenuItem, []int) {
+	arranged, order := items, make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	if item.Sort != nil {
+		arranged, order = sortItems(items, item.Sort)
+	}
+	if item.GroupBy != nil {
+		grouped, groupOrder := groupItems(arranged, item.GroupBy)
+		for k, j := range groupOrder {
+			if j >= 0 {
+				groupOrder[k] = order[j]
+			}
+		}
+		arranged, order = grouped, groupOrder
+	}
+	return arranged, order
+}
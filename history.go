@@ -0,0 +1,83 @@
+package menubar
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// History is a bounded record of activated items, most recent first. Assign
+// one to Model.History to start recording.
+type History struct {
+	Limit   int // Maximum number of entries kept, 0 for unbounded
+	entries []HistoryEntry
+}
+
+type HistoryEntry struct {
+	Path []string  `json:"path"`
+	Time time.Time `json:"time"`
+	Data any       `json:"-"` // Data of the activated item, taken from it again by RestoreState
+
+	action func() tea.Msg
+}
+
+func NewHistory(limit int) *History {
+	return &History{Limit: limit}
+}
+
+// Invoke returns a command that runs the recorded item's Action again.
+func (e HistoryEntry) Invoke() tea.Cmd {
+	return e.action
+}
+
+func (h *History) record(path []string, data any, action func() tea.Msg) {
+	h.add(HistoryEntry{Path: path, Time: time.Now(), Data: data, action: action})
+}
+
+// add puts entry first, dropping the oldest entries past Limit.
+func (h *History) add(entry HistoryEntry) {
+	h.entries = append([]HistoryEntry{entry}, h.entries...)
+	if h.Limit > 0 && len(h.entries) > h.Limit {
+		h.entries = h.entries[:h.Limit]
+	}
+}
+
+// Entries returns the recorded entries, most recent first.
+func (h *History) Entries() []HistoryEntry {
+	return append([]HistoryEntry(nil), h.entries...)
+}
+
+// Last returns the most recent entry, useful for a "Repeat Last Action" item.
+func (h *History) Last() (HistoryEntry, bool) {
+	if len(h.entries) == 0 {
+		return HistoryEntry{}, false
+	}
+	return h.entries[0], true
+}
+
+func (h *History) Clear() {
+	h.entries = nil
+}
+
+// Items returns one item per recorded entry that re-invokes it when activated.
+// Entries without an Action are shown disabled.
+func (h *History) Items() []MenuItem {
+	items := make([]MenuItem, len(h.entries))
+	for i, entry := range h.entries {
+		items[i] = MenuItem{
+			Label:    strings.Join(entry.Path, " > "),
+			Action:   entry.action,
+			Disabled: entry.action == nil,
+			Data:     entry.Data,
+			replays:  entry.Path,
+		}
+	}
+	return items
+}
+
+// Menu returns a "Recent Actions" style item whose submenu is rebuilt from the
+// history each time it opens.
+func (h *History) Menu(label string) MenuItem {
+	return MenuItem{Label: label, SubMenuFunc: h.Items}
+}
@@ -0,0 +1,35 @@
+package menubar
+
+import "github.com/charmbracelet/lipgloss"
+
+// fitInline limits the rows of a dropdown whose top border is top rows below
+// the bar's top so it ends within InlineHeight, scrolling the rest. Pinned
+// items and scroll indicators take rows of their own.
+func (m *Model) fitInline(top int) {
+	m.top = top
+	if !m.InlineMode || m.InlineHeight <= 0 {
+		return
+	}
+	head, tail := m.pinned()
+	space := m.InlineHeight - top - m.Styles.Dropdown.GetVerticalFrameSize()
+	itemHeight := lipgloss.Height(m.Styles.DropdownItem.Render("A"))
+	rows := space/itemHeight - head - tail
+	if visible := m.visibleRows(); visible > 0 && visible < rows {
+		rows = visible
+	}
+	if len(m.Items)-head-tail > rows {
+		// Scrolling adds an indicator row above the items and one below
+		if fit := (space-2)/itemHeight - head - tail; fit < rows {
+			rows = fit
+		}
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	if m.MaxVisibleItems <= 0 || rows < m.MaxVisibleItems {
+		m.MaxVisibleItems = rows
+	}
+	if m.height > rows {
+		m.height = rows
+	}
+}
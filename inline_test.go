@@ -0,0 +1,25 @@
+package menubar
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestInlineHeight(t *testing.T) {
+	for _, pinned := range []bool{false, true} {
+		items := make([]MenuItem, 30)
+		for i := range items {
+			items[i] = MenuItem{Label: fmt.Sprintf("Item %d", i)}
+		}
+		items[len(items)-1].Pinned = pinned
+		m := New([]MenuItem{{Label: "File", SubMenu: items}})
+		m.UsePlain()
+		m.InlineMode, m.InlineHeight = true, 10
+		m.Open(0)
+		if view := m.View(); lipgloss.Height(view) > m.InlineHeight {
+			t.Errorf("pinned %t: expected at most %d rows, got %d:\n%s", pinned, m.InlineHeight, lipgloss.Height(view), view)
+		}
+	}
+}
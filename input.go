@@ -0,0 +1,69 @@
+package menubar
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// inputMinWidth is the room reserved next to the label of an Input item.
+const inputMinWidth = 12
+
+// startInput expands item i into a focused text input.
+func (m *Model) startInput(i int) tea.Cmd {
+	l := m.layoutDropdown()
+	input := textinput.New()
+	input.Prompt = ""
+	input.Width = l.contentWidth() - l.checkWidth - lipgloss.Width(m.tr(m.Items[i].label())) - 2
+	cmd := input.Focus()
+	m.input, m.editing = &input, i
+	return cmd
+}
+
+// inputValue returns the value typed into item i, if it is being edited.
+func (m Model) inputValue(i int) string {
+	if m.input == nil || m.editing != i {
+		return ""
+	}
+	return m.input.Value()
+}
+
+// editingInput reports whether the innermost open menu is editing an input or
+// showing a list, file picker or popup, in which case it takes every key.
+func (m Model) editingInput() bool {
+	if m.hasOpenSubmenu() {
+		return m.SubMenuState.editingInput()
+	}
+	return m.input != nil || m.list != nil || m.picker != nil || m.popup != nil
+}
+
+func (m Model) updateInput(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			return m, m.activate(m.editing)
+		case tea.KeyEsc:
+			m.input = nil
+			return m, nil
+		}
+	}
+	input, cmd := m.input.Update(msg)
+	m.input = &input
+	return m, cmd
+}
+
+// renderInput renders the input of the item being edited, styled to match
+// its row and padded to width.
+func (m Model) renderInput(st itemStyles, width int) string {
+	input := *m.input
+	input.TextStyle = st.base
+	input.PlaceholderStyle = st.base
+	input.Cursor.TextStyle = st.base
+	view := input.View()
+	if pad := width - lipgloss.Width(view); pad > 0 {
+		view += st.base.Render(strings.Repeat(" ", pad))
+	}
+	return view
+}
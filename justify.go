@@ -0,0 +1,55 @@
+package menubar
+
+// Justify controls where the bar places its items when it's given a width.
+type Justify int
+
+const (
+	JustifyLeft         Justify = iota // Items on the left, the right side content on the right
+	JustifyCenter                      // Items centered in the bar
+	JustifyRight                       // Items next to the right side content
+	JustifySpaceBetween                // Items spread evenly across the bar
+)
+
+// barLayout returns the x offset of every bar item, for a bar rendered at
+// width with right side content of rightWidth.
+func (m Model) barLayout(rightWidth, width int) []int {
+	widths := m.barItemWidths()
+	itemsWidth := 0
+	for _, w := range widths {
+		itemsWidth += w
+	}
+
+	lead, gap, extra := 0, 0, 0
+	available := width - m.Styles.Bar.GetHorizontalFrameSize()
+	if free := available - rightWidth - itemsWidth; width > 0 && free > 0 {
+		switch m.Justify {
+		case JustifyCenter:
+			lead = (available - itemsWidth) / 2
+			if lead > free {
+				lead = free
+			}
+		case JustifyRight:
+			lead = free
+		case JustifySpaceBetween:
+			if len(widths) > 1 {
+				gap, extra = free/(len(widths)-1), free%(len(widths)-1)
+			}
+		}
+	}
+
+	xs := make([]int, len(widths))
+	x := lead
+	for i, w := range widths {
+		xs[i] = x
+		x += w + gap
+		if i < extra {
+			x++
+		}
+	}
+	return xs
+}
+
+// barItemX returns the x offset of bar item i in the bar as last rendered.
+func (m Model) barItemX(i int) int {
+	return m.barLayout(m.cache.barSize())[i]
+}
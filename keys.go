@@ -0,0 +1,138 @@
+package menubar
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Terminals name the same key differently: without the enhanced keyboard
+// protocol, Shift+F1 arrives as F13, and a shifted letter as its capital.
+// keyName and canonicalKeys bring pressed keys and the names in Keys to one
+// spelling, so "shift+f1", "alt+shift+s" and "ctrl+alt+x" match either way.
+
+var modifierOrder = map[string]int{"alt": 0, "ctrl": 1, "shift": 2}
+
+// keyName returns the canonical name of a pressed key.
+func keyName(msg tea.KeyMsg) string {
+	return canonicalKey(msg.String())
+}
+
+// canonicalKeys splits a Keys string into canonical key names.
+func canonicalKeys(keys string) []string {
+	fields := strings.Fields(keys)
+	for i, key := range fields {
+		fields[i] = canonicalKey(key)
+	}
+	return fields
+}
+
+func canonicalKey(key string) string {
+	parts := strings.Split(key, "+")
+	base, mods := parts[len(parts)-1], parts[:len(parts)-1]
+	if base == "" && len(mods) > 0 { // The plus key itself, as in "ctrl++"
+		base, mods = "+", mods[:len(mods)-1]
+	}
+
+	// Legacy encodings of Shift+F1 to Shift+F8
+	if n, err := strconv.Atoi(strings.TrimPrefix(base, "f")); err == nil && strings.HasPrefix(base, "f") && n >= 13 && n <= 20 {
+		base = "f" + strconv.Itoa(n-12)
+		mods = append(mods, "shift")
+	}
+
+	seen := make(map[string]bool, len(mods))
+	var kept []string
+	for _, mod := range mods {
+		mod = strings.ToLower(mod)
+		if seen[mod] {
+			continue
+		}
+		seen[mod] = true
+		if mod == "shift" && isLetter(base) {
+			base = strings.ToUpper(base)
+			continue
+		}
+		kept = append(kept, mod)
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return modifierOrder[kept[i]] < modifierOrder[kept[j]] })
+	return strings.Join(append(kept, base), "+")
+}
+
+func isLetter(s string) bool {
+	r := []rune(s)
+	return len(r) == 1 && unicode.IsLetter(r[0])
+}
+
+// HotkeyMatch controls how a pressed key is matched against item hotkeys.
+type HotkeyMatch int
+
+const (
+	HotkeyExactThenFold HotkeyMatch = iota // An exact match, falling back to ignoring case
+	HotkeyExact                            // Only keys matching the Hotkey's case
+	HotkeyFold                             // Keys matching the Hotkey in either case, first item wins
+	HotkeyDropdownsOnly                    // Like HotkeyExactThenFold, but only in open dropdowns, not on the focused bar
+)
+
+// matchHotkey returns the index of the enabled item whose Hotkey matches key
+// under the HotkeyMatch policy, or -1.
+func (m Model) matchHotkey(key string) int {
+	if m.HotkeyMatch == HotkeyDropdownsOnly && !m.isDropdown {
+		return -1
+	}
+	find := func(match func(string) bool) int {
+		for i, item := range m.Items {
+			if !item.IsSeparator && !item.Disabled && item.Hotkey != "" && match(item.Hotkey) {
+				return i
+			}
+		}
+		return -1
+	}
+	exact := func(h string) bool { return key == h }
+	fold := func(h string) bool { return strings.EqualFold(key, h) }
+	switch m.HotkeyMatch {
+	case HotkeyExact:
+		return find(exact)
+	case HotkeyFold:
+		return find(fold)
+	}
+	if i := find(exact); i >= 0 {
+		return i
+	}
+	return find(fold)
+}
+
+// mnemonicKey returns the key to match against item hotkeys, ignoring Alt so
+// that holding it while choosing from an open menu works too.
+func mnemonicKey(msg tea.KeyMsg) string {
+	if msg.Alt && msg.Type == tea.KeyRunes {
+		return string(msg.Runes)
+	}
+	return msg.String()
+}
+
+// openMnemonic focuses the bar and opens the top-level item whose Hotkey was
+// pressed with Alt, if AltMnemonics is set and the bar isn't focused.
+func (m *Model) openMnemonic(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if !m.AltMnemonics || m.Active || m.isDropdown || m.collapsed() || !msg.Alt || msg.Type != tea.KeyRunes {
+		return nil, false
+	}
+	// Alt is deliberate, so the mnemonic works under HotkeyDropdownsOnly too.
+	policy := *m
+	if policy.HotkeyMatch == HotkeyDropdownsOnly {
+		policy.HotkeyMatch = HotkeyExactThenFold
+	}
+	i := policy.matchHotkey(string(msg.Runes))
+	if i < 0 {
+		return nil, false
+	}
+	m.Active = true
+	m.Selection = i
+	if !m.Items[i].hasSubMenu() {
+		return m.activate(i), true
+	}
+	m.openCurrentSelection()
+	return nil, true
+}
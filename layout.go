@@ -0,0 +1,24 @@
+package menubar
+
+// PlaceInCell puts the bar in a cell of a layout manager, like a flexbox cell
+// of github.com/76creates/stickers, at screen position x, y and width columns
+// wide. The bar renders across the cell and mouse events, which arrive in
+// screen coordinates, resolve to its items. The cell's height is fixed at
+// BarHeight while its width flexes, so call it again whenever the layout is
+// resized, after passing the tea.WindowSizeMsg to Update.
+func (m *Model) PlaceInCell(x, y, width int) {
+	m.X, m.Y = x, y
+	m.SetWidth(width)
+}
+
+// OverlayDropdowns draws the open dropdowns, and the panes beside them, onto
+// view, the finished layout with ViewBar as the cell's content.
+func (m Model) OverlayDropdowns(view string) string {
+	if m.isDropdown {
+		return view
+	}
+	for _, layer := range m.ViewLayers("", 0)[1:] {
+		view = Overlay(view, layer.Content, layer.X, layer.Y)
+	}
+	return view
+}
@@ -0,0 +1,52 @@
+package menubar
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ListSelectedMsg is sent when an item is chosen from the list of an item
+// with List, which then closes.
+type ListSelectedMsg struct {
+	MenuID string
+	Path   []string // Labels from the top-level item down to the item with the List
+	Data   any      // Data of the item with the List
+	Item   list.Item
+}
+
+func (msg ListSelectedMsg) menuID() string { return msg.MenuID }
+
+// openList opens the list of the selected item as its dropdown.
+func (m *Model) openList(item MenuItem) {
+	l := item.List()
+	l.KeyMap.Quit.SetEnabled(false) // Esc closes the dropdown instead
+	sub := m.newSubMenu(item, nil)
+	sub.list = &l
+	m.OpenSubMenu = m.Selection
+	m.SubMenuState = &sub
+}
+
+// updateList passes messages to the list, choosing its selected item on enter
+// and closing it on esc, unless it is being filtered.
+func (m Model) updateList(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && m.list.FilterState() != list.Filtering {
+		switch msg.String() {
+		case "enter":
+			item := m.list.SelectedItem()
+			if item == nil {
+				return m, nil
+			}
+			m.Active = false
+			selected := ListSelectedMsg{MenuID: m.ID, Path: m.path, Data: m.data, Item: item}
+			return m, func() tea.Msg { return selected }
+		case "esc":
+			if m.list.FilterState() == list.Unfiltered {
+				m.Active = false
+				return m, nil
+			}
+		}
+	}
+	l, cmd := m.list.Update(msg)
+	m.list = &l
+	return m, cmd
+}
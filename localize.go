@@ -0,0 +1,27 @@
+package menubar
+
+// Localizer translates the labels and descriptions of items, which then hold
+// message keys instead of text. Paths in messages keep the keys.
+type Localizer interface {
+	T(key string) string
+}
+
+// tr translates key with the Localizer, if there is one.
+func (m Model) tr(key string) string {
+	if m.Localizer == nil || key == "" {
+		return key
+	}
+	return m.Localizer.T(key)
+}
+
+// SetLocalizer switches the Localizer, e.g. when the user picks another
+// language, including for the menus already open.
+func (m *Model) SetLocalizer(l Localizer) {
+	m.touch()
+	m.changedItems()
+	for _, menu := range []*Model{m, m.burger} {
+		for ; menu != nil; menu = menu.SubMenuState {
+			menu.Localizer = l
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package menubar
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type dictionary map[string]string
+
+func (d dictionary) T(key string) string { return d[key] }
+
+func TestSetLocalizer(t *testing.T) {
+	english := dictionary{"file": "File", "open": "Open", "edit": "Edit", "undo": "Undo"}
+	german := dictionary{"file": "Datei", "open": "Öffnen...", "edit": "Bearbeiten", "undo": "Rückgängig"}
+	localized := func(l Localizer) Model {
+		m := New([]MenuItem{
+			{Label: "file", SubMenu: []MenuItem{{Label: "open"}}},
+			{Label: "edit", SubMenu: []MenuItem{{Label: "undo"}}},
+		})
+		m.UsePlain()
+		m.Localizer = l
+		m.Open(0)
+		return m
+	}
+
+	m := localized(english)
+	m.View()
+	m.SetLocalizer(german)
+	if got, want := m.View(), localized(german).View(); got != want {
+		t.Errorf("expected the German menus:\n%s\ngot:\n%s", want, got)
+	}
+
+	// Hit testing follows the German widths, past the end of the English bar
+	for _, typ := range []tea.MouseEventType{tea.MouseLeft, tea.MouseRelease} {
+		m, _ = m.Update(tea.MouseMsg{X: 14, Y: 0, Type: typ})
+	}
+	if m.OpenSubMenu != 1 {
+		t.Errorf("expected the click to open Bearbeiten, got menu %d open", m.OpenSubMenu)
+	}
+}
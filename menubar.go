@@ -1,30 +1,97 @@
 package menubar
 
 import (
+	"context"
 	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/filepicker"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/jejacks0n/bubbletea-menubar/overlay"
 )
 
 var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
 type MenuItem struct {
-	Label       string
-	Hotkey      string
-	Shortcut    string
-	Action      func() tea.Msg
-	SubMenu     []MenuItem
-	IsSeparator bool
-	Disabled    bool
+	ID           string // Identifies the item for Merge and InsertAfterID, defaults to its label
+	Label        string
+	LabelFunc    func() string // Shown instead of Label, evaluated each render, e.g. "Zoom: 120%"
+	CheckedLabel string        // Shown instead of Label while Checked, e.g. a muted speaker icon
+	Hotkey       string
+	Shortcut     string
+	Value        string // Current value of a setting, shown dimly before the shortcut
+	Keys         string // Key sequence that activates the item from anywhere, e.g. "ctrl+s" or "ctrl+k ctrl+s"
+	Action       func() tea.Msg
+	ActionErr    func() (tea.Msg, error)           // Like Action, but a returned error is sent as an ActionErrorMsg
+	ActionCtx    func(ctx context.Context) tea.Msg // Like Action, but cancelled when the item is activated again
+	Exec         string                            // Program run in place of the TUI when activated, e.g. "vim"
+	ExecArgs     []string                          // Arguments for Exec
+	OnExit       func(err error) tea.Msg           // Called when Exec exits, instead of sending an ExecFinishedMsg
+	SubMenu      []MenuItem
+	IsSeparator  bool
+	Disabled     bool
+	Checkable    bool   // Toggles Checked when activated
+	Checked      bool   // Renders a checkmark in dropdowns
+	Mixed        bool   // Renders a checkbox as partially checked, until it is toggled
+	Modified     bool   // Marks a top-level item, e.g. File when there are unsaved changes
+	RadioGroup   string // Checking this item unchecks others in the same group
+	OnToggle     func(checked bool) tea.Cmd
+	SubMenuFunc  func() []MenuItem          // Builds the submenu each time it opens, instead of SubMenu
+	Confirm      string                     // Asks this question with a Yes/No prompt before activating
+	Input        func(value string) tea.Msg // Expands into a text input in dropdowns, submitting its value
+	MinWidth     int                        // Minimum width of the submenu's dropdown, including its border
+	Tags         []string                   // Groups related items for EnableTag and CheckTag
+	Width        int                        // Fixed width of the submenu's dropdown, truncating long labels
+	Height       int                        // Rows the submenu's dropdown shows before scrolling, instead of MaxVisibleItems
+	Pinned       bool                       // Keeps the item in place when it starts or ends a scrolling dropdown, like a footer
+	GroupBy      func(item MenuItem) string // Gathers the submenu into groups under headers as it opens, e.g. recent files by directory
+	Data         any                        // Payload of generated items, like a file path, sent along in ActivatedMsg
+	Preview      func() string              // Content shown beside the dropdown while the item is selected, like a file's first lines
+	Description  string                     // Longer explanation for generated documentation, see ExportMarkdown
+	Sort         SortFunc                   // Orders the submenu as it opens, without changing SubMenu
+	List         func() list.Model          // Builds a list shown in place of a submenu, for long filterable choices
+	FilePicker   func() filepicker.Model    // Builds a file picker shown in place of a submenu, for "Open…" items
+	Dialog       func() tea.Model           // Builds a model, like a huh form, shown centered until it returns CloseDialog
+	Popup        func() tea.Model           // Builds a model, like a color picker, shown in place of a submenu until it returns ClosePopup
+
+	replays []string // Path of the item re-invoked by a history entry
+	header  bool     // Inserted above a group by GroupBy
 }
 
 func Separator() MenuItem {
 	return MenuItem{IsSeparator: true}
 }
 
+// Toggle returns a top-level item that shows label, or checkedLabel while
+// checked, and toggles without opening a dropdown, like a tray icon.
+func Toggle(label, checkedLabel string, onToggle func(checked bool) tea.Cmd) MenuItem {
+	return MenuItem{Label: label, CheckedLabel: checkedLabel, Checkable: true, OnToggle: onToggle}
+}
+
+// label returns the label the item is shown with.
+func (item MenuItem) label() string {
+	if item.Checked && item.CheckedLabel != "" {
+		return item.CheckedLabel
+	}
+	if item.LabelFunc != nil {
+		return item.LabelFunc()
+	}
+	return item.Label
+}
+
+func (item MenuItem) hasSubMenu() bool {
+	return len(item.SubMenu) > 0 || item.SubMenuFunc != nil || item.List != nil || item.FilePicker != nil || item.Popup != nil
+}
+
 type Model struct {
+	ID           string // Identifies this menubar when several coexist
+	X, Y         int    // Screen position of the bar, used for mouse hit testing
 	Items        []MenuItem
 	Active       bool
 	Selection    int
@@ -33,9 +100,131 @@ type Model struct {
 
 	// Styling
 	Styles Styles
+	Glyphs Glyphs
+
+	History *History // Records activated items when set
+
+	MaxVisibleItems int // Rows shown before a dropdown scrolls, 0 for no limit
+
+	// Inline mode, for programs that don't use the alternate screen
+	InlineMode   bool // Keep dropdowns within InlineHeight
+	InlineHeight int  // Rows the program's view spans, bar included
+
+	// Shortcut column
+	ShortcutGap   int               // Columns between the labels and the shortcuts
+	ShortcutAlign lipgloss.Position // lipgloss.Left or lipgloss.Right
+	HideShortcuts bool              // Leave out the shortcut column, for compact layouts
+
+	ShowDescriptions bool // Show each item's Description on a second line in dropdowns, like a command palette
+
+	Justify      Justify // Placement of the items when the bar is rendered at a width
+	UniformWidth bool    // Render every bar item as wide as the widest one, like tabs
+
+	CollapseWidth int // Below this width the bar collapses into a single menu button
+
+	ClickOutside ClickOutside // What a click outside the menus does while focused
+
+	DisableMouse    bool // Ignore mouse events, leaving them to the host
+	DisableKeyboard bool // Ignore keys, including Keys shortcuts, for menus driven by the mouse or the host
+
+	ChordTimeout time.Duration // How long a chord of Keys waits for its next key
+	AltMnemonics bool          // Alt with a top-level Hotkey opens its menu while the bar isn't focused
+	HotkeyMatch  HotkeyMatch   // How keys are matched against item hotkeys, see HotkeyMatch
+	SearchKey    string        // Starts a search of every menu's labels while the bar is focused, like "/"; unbound when empty
+	ReopenKey    string        // Reopens the menus closed last, see ReopenLast; unbound when empty
+	CoalesceKeys time.Duration // Adds up repeated up/down keys within this long into one movement, for expensive dropdowns
+
+	Widgets []Widget // Shown on the right side of the bar, see ClockWidget
+
+	ToastCorner Corner // Where toasts are stacked, see Toast
+
+	CloseOnBlur bool // Close the dropdowns when the terminal window loses focus, see SetTerminalFocus
+
+	Debug bool // Include hitbox outlines in ViewDropdownLayers
+
+	Localizer Localizer // Translates item labels and descriptions when set
+
+	// Accessibility
+	Accessible bool // Emit an AnnouncementMsg when the focused item changes
+
+	OnEvent func(Event) // Called as menus open and close, selection moves and items activate
+
+	// Render hooks, for decorating the views or timing them. Decorations
+	// shouldn't move what was rendered, since mouse handling relies on it.
+	BeforeRender     func(path []string)                         // Called before rendering the bar, with a nil path, or the dropdown opened by path
+	OnRenderBar      func(bar string) string                     // Replaces the rendered bar
+	OnRenderDropdown func(path []string, dropdown string) string // Replaces the rendered dropdown opened by path
+
+	middleware []Middleware
+	failed     string // Label of the top-level item whose action last failed
+	blurred    bool   // True while the terminal window doesn't have focus
+	prompt     bool   // True if this dropdown is a confirmation prompt
+	confirmed  bool   // True once a confirmation prompt was answered with Yes
+
+	input   *textinput.Model // Text input of the item being edited, nil if none
+	editing int              // Index of the item being edited
+
+	list   *list.Model       // List shown by this dropdown in place of items, see MenuItem.List
+	picker *filepicker.Model // File picker shown in place of items, see MenuItem.FilePicker
+	popup  tea.Model         // Model shown in place of items, see MenuItem.Popup
+	starts tea.Cmd           // Init command of a popup opened during the current update
+
+	dialog tea.Model         // Dialog opened by an item, on the bar; see MenuItem.Dialog
+	screen tea.WindowSizeMsg // Size of the screen as last reported, for centering dialogs
+
+	renderer *lipgloss.Renderer // Renders the styles instead of the default renderer, see SetRenderer
+
+	minWidth, width int // Dropdown widths from the item that opened it
+	sizedWidth      int // Width the bar renders at when a view isn't given one, see SetWidth
+	height          int // Dropdown height from the item that opened it, see visibleRows
+
+	burger *Model // The open dropdown of the collapsed bar
+
+	revision uint64 // Counts changes to what the menubar shows, see Revision
+
+	itemsGen uint64 // Generation of Items renders are keyed on, telling dropdowns apart
+	frameSum uint64 // stylesSum of the view being rendered, see frame
+
+	chord    []string // Keys pressed so far of a chord
+	chordSeq int      // Identifies the pending chord's timeout
+
+	flashing   bool // True while flashIndex is highlighted after its shortcut was used
+	flashIndex int
+	flashSeq   int // Identifies the current flash's end
+
+	message    string // Shown in place of the right side, see Flash
+	messageSeq int
+
+	search   *search     // Shown in place of the dropdowns while searching, see SearchKey
+	lastOpen *ModelState // Menus open before the bar last closed them, see ReopenLast
+
+	toasts   []toast // Shown in the ToastCorner, oldest first, see Toast
+	toastSeq int
+
+	progress     float64 // See SetProgress
+	showProgress bool
+
+	busy    bool // See SetBusy
+	spinner spinner.Model
+
+	coalescing bool // Within the window of CoalesceKeys
+	pending    int  // Net rows moved by the up and down keys held back in it
+	queueSeq   int
+
+	context  string                  // Name of the current menu set
+	contexts map[string]*menuContext // Menu sets added with AddContext
 
 	// Configuration
-	isDropdown bool // True if this model represents a dropdown menu
+	isDropdown bool         // True if this model represents a dropdown menu
+	path       []string     // Labels leading to this menu, empty for the bar
+	data       any          // Data of the item that opened this dropdown
+	source     []MenuItem   // The unsorted items of a sorted dropdown
+	top        int          // Row of this dropdown's top border, relative to the bar
+	order      []int        // Index in source of each item
+	scroll     int          // Index of the first visible item in a scrolling dropdown
+	openedAt   time.Time    // When this dropdown was opened
+	cache      *renderCache // Shared with submenus, nil disables caching
+	actions    *actionContexts
 }
 
 type Styles struct {
@@ -49,7 +238,24 @@ type Styles struct {
 	ShortcutSelected lipgloss.Style
 	Hotkey           lipgloss.Style
 	Separator        lipgloss.Style
+	BarSeparator     lipgloss.Style // Separator items in the bar, colored like disabled items unless set
 	Disabled         lipgloss.Style
+	Error            lipgloss.Style // Applied to a top-level item after one of its actions fails
+	Unfocused        lipgloss.Style // Applied to the bar items while the terminal window doesn't have focus
+	Spacer           lipgloss.Style // Space between the bar items and the right side, like Bar unless set
+	Right            lipgloss.Style // The right side of the bar, like Bar unless set
+	Message          lipgloss.Style // Messages shown in place of the right side by Flash
+	Description      lipgloss.Style // Second line of dropdown items, see ShowDescriptions
+	ScrollIndicator  lipgloss.Style // Rows above and below the items of a scrolling dropdown
+	GroupHeader      lipgloss.Style // Headers inserted by GroupBy
+	Match            lipgloss.Style // Characters of search results matching the query, see SearchKey
+	Preview          lipgloss.Style // Frame of the pane showing an item's Preview
+	Toast            lipgloss.Style // Frame and padding of every toast, and the style of ToastInfo ones
+	ToastSuccess     lipgloss.Style
+	ToastWarning     lipgloss.Style
+	ToastError       lipgloss.Style
+
+	BarGradient []lipgloss.Color // Blended across the bar's background, behind everything but the selected item
 }
 
 type DropdownLayer struct {
@@ -58,7 +264,22 @@ type DropdownLayer struct {
 	Y       int
 }
 
+// ClickOutside controls what a click outside the bar and its dropdowns does
+// while the menubar is focused.
+type ClickOutside int
+
+const (
+	CloseAll       ClickOutside = iota // Close the dropdowns and unfocus the bar
+	CloseDropdowns                     // Close the dropdowns but keep the bar focused
+	IgnoreOutside                      // Leave everything open
+)
+
+// DefaultStyles returns the default color theme, or MonochromeStyles when
+// NO_COLOR is set to a non-empty value or the terminal can't render colors.
 func DefaultStyles() Styles {
+	if noColor() {
+		return MonochromeStyles()
+	}
 	return Styles{
 		Bar: lipgloss.NewStyle().
 			Background(lipgloss.Color("#5F00FF")).
@@ -93,31 +314,233 @@ func DefaultStyles() Styles {
 		Disabled: lipgloss.NewStyle().
 			Padding(0, 1).
 			Foreground(lipgloss.Color("#666666")),
+		Error: lipgloss.NewStyle().
+			Background(lipgloss.Color("#D70000")).
+			Foreground(lipgloss.Color("#FFFFFF")),
+		Unfocused: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#666666")),
+		Message: lipgloss.NewStyle().
+			Bold(true),
+		Description: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#808080")),
+		ScrollIndicator: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#666666")),
+		GroupHeader: lipgloss.NewStyle().
+			Padding(0, 1).
+			Bold(true).
+			Foreground(lipgloss.Color("#808080")),
+		Match: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FF5FAF")),
+		Preview: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#5F5FD7")).
+			Padding(0, 1),
+		Toast: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#5F5FD7")).
+			Padding(0, 1),
+		ToastSuccess: lipgloss.NewStyle().
+			BorderForeground(lipgloss.Color("#00AF5F")),
+		ToastWarning: lipgloss.NewStyle().
+			BorderForeground(lipgloss.Color("#FFAF00")),
+		ToastError: lipgloss.NewStyle().
+			BorderForeground(lipgloss.Color("#D70000")),
 	}
 }
 
 func New(items []MenuItem) Model {
-	return Model{
-		Items:       items,
-		Styles:      DefaultStyles(),
-		OpenSubMenu: -1,
-		Selection:   0,
-		Active:      true,
+	m := Model{
+		Items:  items,
+		Styles: DefaultStyles(),
+		Glyphs: DefaultGlyphs(),
+
+		ShortcutGap:   2,
+		ShortcutAlign: lipgloss.Right,
+		ChordTimeout:  time.Second,
+		OpenSubMenu:   -1,
+		Selection:     0,
+		Active:        true,
+		cache:         newRenderCache(),
+		actions:       newActionContexts(),
 	}
+	if !unicodeSupported() {
+		m.UseASCII()
+	}
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
-	return nil
+	cmds := make([]tea.Cmd, len(m.Widgets))
+	for i, w := range m.Widgets {
+		cmds[i] = w.Init()
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if m.isDropdown {
+		return m.update(msg)
+	}
+	if m.mayChange(msg) {
+		m.touch()
+	}
+	widgets := m.updateWidgets(msg)
+	dialog, handled := m.updateDialog(msg)
+	if handled {
+		return m, tea.Batch(widgets, dialog)
+	}
+	widgets = tea.Batch(widgets, dialog)
+	var flushed tea.Cmd
+	switch msg := msg.(type) {
+	case CopiedMsg:
+		m.setTerminalClipboard(msg.Text)
+	case CutMsg:
+		m.setTerminalClipboard(msg.Text)
+	case tea.WindowSizeMsg:
+		m.SetWidth(msg.Width - m.X)
+	case tea.MouseMsg:
+		if m.DisableMouse {
+			return m, widgets
+		}
+	case tea.KeyMsg:
+		if m.DisableKeyboard {
+			return m, widgets
+		}
+		if m.coalesces(msg) {
+			if m.coalescing {
+				m.holdKey(msg)
+				return m, widgets
+			}
+			flushed = m.startCoalescing()
+		} else if m.coalescing {
+			m, flushed = m.flushKeys()
+		}
+	case keyFlushMsg:
+		if msg.menuID == m.ID && msg.seq == m.queueSeq {
+			m, flushed = m.flushKeys()
+		}
+		return m, tea.Batch(widgets, flushed)
+	}
+	m, cmd := m.updateBar(msg)
+	return m, tea.Batch(widgets, flushed, cmd, m.startPopups())
+}
+
+func (m Model) updateBar(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.search != nil && m.Active {
+			return m.updateSearch(msg)
+		}
+		if m.startSearch(msg) {
+			return m, nil
+		}
+		if cmd, ok := m.HandleShortcut(msg); ok {
+			return m, cmd
+		}
+		if cmd, ok := m.openMnemonic(msg); ok {
+			return m, cmd
+		}
+	case chordTimeoutMsg:
+		if msg.menuID == m.ID && msg.seq == m.chordSeq {
+			m.chord = nil
+		}
+		return m, nil
+	case flashDoneMsg:
+		if msg.menuID == m.ID && msg.seq == m.flashSeq {
+			m.endFlash()
+		}
+		return m, nil
+	case flashMessageDoneMsg:
+		if msg.menuID == m.ID && msg.seq == m.messageSeq {
+			m.message = ""
+		}
+		return m, nil
+	case toastMsg:
+		return m, m.addToast(msg)
+	case toastExpiredMsg:
+		if msg.menuID == m.ID {
+			m.removeToast(msg.id)
+		}
+		return m, nil
+	case spinner.TickMsg:
+		return m, m.updateSpinner(msg)
+	case tea.MouseMsg:
+		if msg.Type != tea.MouseMotion {
+			m.search = nil
+		}
+	}
+	if m.collapsed() {
+		return m.updateCollapsed(msg)
+	}
+	m.burger = nil
+	return m.updateTracked(msg)
+}
+
+// updateTracked runs update, announcing what it changed to screen readers and
+// reporting it to OnEvent.
+func (m Model) updateTracked(msg tea.Msg) (Model, tea.Cmd) {
+	var announcement string
+	if m.Accessible {
+		announcement = m.announcement()
+	}
+	var snapshot menuSnapshot
+	if m.OnEvent != nil {
+		snapshot = m.snapshot()
+	}
+
+	m, cmd := m.update(msg)
+
+	if m.Accessible {
+		if after := m.announcement(); after != announcement {
+			cmd = tea.Batch(cmd, m.announce(after))
+		}
+	}
+	if m.OnEvent != nil {
+		m.logChanges(snapshot)
+	}
+	return m, cmd
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.isDropdown {
+		switch msg := msg.(type) {
+		case ActionErrorMsg:
+			if m.Owns(msg) && len(msg.Path) > 0 {
+				m.failed = msg.Path[0]
+			}
+			return m, nil
+		case tea.KeyMsg:
+			m.failed = ""
+		case tea.MouseMsg:
+			if msg.Type != tea.MouseMotion {
+				m.failed = ""
+			}
+		}
+	}
+
 	// Handle mouse always to allow activation on click
 	if mouseMsg, ok := msg.(tea.MouseMsg); ok {
 		return m.handleMouse(mouseMsg)
 	}
 
+	if focused, ok := terminalFocus(msg); ok && !m.isDropdown {
+		m.SetTerminalFocus(focused)
+		return m, nil
+	}
+
+	// Focus routing between coexisting menubars
+	if focusMsg, ok := msg.(FocusMsg); ok && !m.isDropdown {
+		m.Active = focusMsg.MenuID == m.ID
+		if !m.Active {
+			m.closeSubMenu()
+		}
+		return m, nil
+	}
+
 	// Ensure selection is valid (e.g. if first item is disabled)
 	m.ensureValidSelection()
+	m.repairSubMenu()
 
 	if !m.Active {
 		return m, nil
@@ -126,7 +549,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	// Handle navigation when a submenu is open
 	if m.OpenSubMenu != -1 && m.SubMenuState != nil {
 		// We need to intercept Left/Right for top-level navigation if we are the top bar
-		if !m.isDropdown {
+		if !m.isDropdown && !m.SubMenuState.editingInput() {
 			switch msg := msg.(type) {
 			case tea.KeyMsg:
 				switch msg.String() {
@@ -175,51 +598,39 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 
 		// Delegate to submenu
-		newSubModel, cmd := m.SubMenuState.Update(msg)
+		newSubModel, cmd := m.SubMenuState.update(msg)
+		newSubModel.scrollToSelection()
 		m.SubMenuState = &newSubModel
 
 		// Check if submenu closed itself (e.g. via Esc or Left in dropdown)
-		if !m.SubMenuState.Active {
-			m.OpenSubMenu = -1
-			m.SubMenuState = nil
-		}
-		return m, cmd
+		return m, tea.Batch(cmd, m.settleSubMenu())
+	}
+
+	if m.input != nil {
+		return m.updateInput(msg)
+	}
+	if m.list != nil {
+		return m.updateList(msg)
+	}
+	if m.picker != nil {
+		return m.updateFilePicker(msg)
+	}
+	if m.popup != nil {
+		return m.updatePopup(msg)
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		key := msg.String()
+		key := mnemonicKey(msg)
 
 		// Check for hotkeys
-		// 1. Exact match (case-sensitive)
-		for i, item := range m.Items {
-			if item.IsSeparator || item.Disabled {
-				continue
-			}
-			if item.Hotkey != "" && key == item.Hotkey {
-				m.Selection = i
-				if len(item.SubMenu) > 0 {
-					m.openCurrentSelection()
-				} else if item.Action != nil {
-					return m, func() tea.Msg { return item.Action() }
-				}
-				return m, nil
-			}
-		}
-		// 2. Fallback to case-insensitive match
-		for i, item := range m.Items {
-			if item.IsSeparator || item.Disabled {
-				continue
-			}
-			if item.Hotkey != "" && strings.EqualFold(key, item.Hotkey) {
-				m.Selection = i
-				if len(item.SubMenu) > 0 {
-					m.openCurrentSelection()
-				} else if item.Action != nil {
-					return m, func() tea.Msg { return item.Action() }
-				}
+		if i := m.matchHotkey(key); i >= 0 {
+			m.Selection = i
+			if m.Items[i].hasSubMenu() {
+				m.openCurrentSelection()
 				return m, nil
 			}
+			return m, m.activate(i)
 		}
 
 		switch key {
@@ -248,7 +659,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			if m.isDropdown {
 				// If current item has submenu, open it
 				item := m.Items[m.Selection]
-				if len(item.SubMenu) > 0 {
+				if item.hasSubMenu() {
 					m.openCurrentSelection()
 				}
 			} else {
@@ -270,39 +681,19 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			}
 		case "up":
 			if m.isDropdown {
-				m.Selection--
-				if m.Selection < 0 {
-					m.Selection = len(m.Items) - 1
-				}
-				// Skip separators and disabled items
-				start := m.Selection
-				for m.Items[m.Selection].IsSeparator || m.Items[m.Selection].Disabled {
-					m.Selection--
-					if m.Selection < 0 {
-						m.Selection = len(m.Items) - 1
-					}
-					if m.Selection == start {
-						break
-					}
-				}
+				m.selectFrom(m.Selection-1, -1)
+			}
+		case "home":
+			if m.isDropdown {
+				m.selectFrom(0, 1)
+			}
+		case "end":
+			if m.isDropdown {
+				m.selectFrom(len(m.Items)-1, -1)
 			}
 		case "down":
 			if m.isDropdown {
-				m.Selection++
-				if m.Selection >= len(m.Items) {
-					m.Selection = 0
-				}
-				// Skip separators and disabled items
-				start := m.Selection
-				for m.Items[m.Selection].IsSeparator || m.Items[m.Selection].Disabled {
-					m.Selection++
-					if m.Selection >= len(m.Items) {
-						m.Selection = 0
-					}
-					if m.Selection == start {
-						break
-					}
-				}
+				m.selectFrom(m.Selection+1, 1)
 			} else {
 				// Open menu
 				if len(m.Items) > 0 {
@@ -312,21 +703,20 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		case "enter":
 			if len(m.Items) > 0 {
 				item := m.Items[m.Selection]
-				if item.Disabled {
+				if item.Disabled || item.IsSeparator {
 					return m, nil
 				}
-				if len(item.SubMenu) > 0 {
+				if item.hasSubMenu() {
 					m.openCurrentSelection()
-				} else if item.Action != nil {
-					return m, func() tea.Msg { return item.Action() }
+				} else {
+					return m, m.activate(m.Selection)
 				}
 			}
 		case "esc":
 			if m.isDropdown {
 				m.Active = false
 			} else {
-				m.OpenSubMenu = -1
-				m.SubMenuState = nil
+				m.closeSubMenu()
 			}
 		}
 	}
@@ -335,6 +725,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
+	m = m.frame()
 	if m.isDropdown {
 		return m.viewDropdown()
 	}
@@ -342,16 +733,23 @@ func (m Model) View() string {
 }
 
 func (m Model) ViewWithRightSide(right string, width int) string {
+	m = m.frame()
 	if m.isDropdown {
 		return m.viewDropdown()
 	}
 	bar := m.renderBarContent(right, width)
-	dropdown, offset := m.ViewDropdown()
-
-	if dropdown != "" {
-		return lipgloss.JoinVertical(lipgloss.Top, bar, lipgloss.NewStyle().MarginLeft(offset).Render(dropdown))
+	view := bar
+	if dropdown, offset := m.ViewDropdown(); dropdown != "" {
+		view = lipgloss.JoinVertical(lipgloss.Top, bar, lipgloss.NewStyle().MarginLeft(offset).Render(dropdown))
 	}
-	return bar
+	// The view doesn't reach the bottom of the screen, so toasts stack below
+	// the bar whatever the corner
+	t := m
+	t.screen.Height = 0
+	for _, layer := range t.toastLayers(lipgloss.Width(bar)) {
+		view = overlay.Place(view, layer.Content, layer.X-m.X, layer.Y-m.Y)
+	}
+	return view
 }
 
 func (m Model) ViewBar() string {
@@ -362,6 +760,7 @@ func (m Model) ViewBar() string {
 }
 
 func (m Model) ViewBarWithRightSide(right string, width int) string {
+	m = m.frame()
 	if m.isDropdown {
 		return ""
 	}
@@ -369,6 +768,13 @@ func (m Model) ViewBarWithRightSide(right string, width int) string {
 }
 
 func (m Model) ViewDropdown() (string, int) {
+	m = m.frame()
+	if m.collapsed() {
+		return m.hamburger().ViewDropdown()
+	}
+	if m.search != nil && m.Active {
+		return m.searchLayer().Content, 0
+	}
 	if m.OpenSubMenu != -1 && m.SubMenuState != nil {
 		dropdown := m.SubMenuState.View()
 		offset := m.getDropdownOffset()
@@ -378,54 +784,81 @@ func (m Model) ViewDropdown() (string, int) {
 }
 
 func (m Model) ViewDropdownLayers() ([]DropdownLayer, int) {
-	if m.OpenSubMenu != -1 && m.SubMenuState != nil {
-		offset := m.getDropdownOffset()
-		layers := m.SubMenuState.getLayersRecursive(0, 0)
-		return layers, offset
+	m = m.frame()
+	layers, offset := m.dropdownLayers()
+	_, width := m.cache.barSize()
+	for _, layer := range m.toastLayers(width) {
+		layer.X -= m.X + offset
+		layer.Y -= m.Y + m.BarHeight()
+		layers = append(layers, layer)
 	}
-	return nil, 0
+	return layers, offset
 }
 
-func Overlay(bg string, fg string, x, y int) string {
-	bgLines := strings.Split(bg, "\n")
-	fgLines := strings.Split(fg, "\n")
-
-	for i, fgLine := range fgLines {
-		row := y + i
-		if row >= len(bgLines) {
-			bgLines = append(bgLines, strings.Repeat(" ", x)+fgLine)
-			continue
-		}
-
-		bgLine := bgLines[row]
-		bgWidth := lipgloss.Width(bgLine)
-
-		if bgWidth < x {
-			padding := strings.Repeat(" ", x-bgWidth)
-			bgLines[row] = bgLine + padding + fgLine
-			continue
+// dropdownLayers returns the layers of ViewDropdownLayers, without toasts.
+func (m Model) dropdownLayers() ([]DropdownLayer, int) {
+	if m.collapsed() {
+		return m.hamburger().dropdownLayers()
+	}
+	if m.search != nil && m.Active {
+		return []DropdownLayer{m.searchLayer()}, 0
+	}
+	var layers []DropdownLayer
+	offset := 0
+	if m.OpenSubMenu != -1 && m.SubMenuState != nil {
+		offset = m.getDropdownOffset()
+		layers = m.SubMenuState.getLayersRecursive(0, 0)
+		if preview, ok := m.previewLayer(m.X + offset); ok {
+			layers = append(layers, preview)
 		}
+	}
+	if m.Debug {
+		layers = append(layers, m.hitboxLayers(m.X+offset, m.Y+m.BarHeight())...)
+	}
+	return layers, offset
+}
 
-		prefix, _ := splitWithANSI(bgLine, x)
-
-		fgWidth := lipgloss.Width(fgLine)
-		suffixStart := x + fgWidth
-
-		preSuffix, suffix := splitWithANSI(bgLine, suffixStart)
+// ViewLayers returns the bar, rendered like ViewBarWithRightSide, followed by
+// the layers of ViewDropdownLayers, all positioned absolutely from X and Y so
+// they can be composed with a single loop.
+func (m Model) ViewLayers(right string, width int) []DropdownLayer {
+	m = m.frame()
+	if m.isDropdown {
+		return nil
+	}
+	layers := []DropdownLayer{{Content: m.renderBarContent(right, width), X: m.X, Y: m.Y}}
+	dropdowns, offset := m.dropdownLayers()
+	top := m.Y + m.BarHeight()
+	for _, layer := range dropdowns {
+		layer.X += m.X + offset
+		layer.Y += top
+		layers = append(layers, layer)
+	}
+	if m.dialog != nil {
+		layers = append(layers, m.dialogLayer(width))
+	}
+	return append(layers, m.toastLayers(width)...)
+}
 
-		ansiCodes := strings.Join(ansiRegex.FindAllString(preSuffix, -1), "")
-		suffix = ansiCodes + suffix
+// Overlay draws fg over bg with its top left corner at column x of row y, see
+// overlay.Place.
+func Overlay(bg string, fg string, x, y int) string {
+	return overlay.Place(bg, fg, x, y)
+}
 
-		prefixWidth := lipgloss.Width(prefix)
-		padding := ""
-		if prefixWidth < x {
-			padding = strings.Repeat(" ", x-prefixWidth)
+// selectFrom selects the first item that isn't a separator or disabled,
+// starting at i and stepping by delta, wrapping around the ends. The
+// selection is left alone if there is none.
+func (m *Model) selectFrom(i, delta int) {
+	n := len(m.Items)
+	for tries := 0; tries < n; tries++ {
+		i = (i%n + n) % n
+		if !m.Items[i].IsSeparator && !m.Items[i].Disabled {
+			m.Selection = i
+			return
 		}
-
-		// bgLines[row] = prefix + padding + fgLine + suffix
-		bgLines[row] = prefix + padding + "\x1b[0m" + fgLine + suffix
+		i += delta
 	}
-	return strings.Join(bgLines, "\n")
 }
 
 func (m *Model) ensureValidSelection() bool {
@@ -464,25 +897,205 @@ func (m *Model) ensureValidSelection() bool {
 	}
 }
 
+// openCurrentSelection builds the model for the selected item's submenu. Models
+// are only ever built for the submenus along the open path, one level at a
+// time, and SubMenuFunc items don't even build their items until then.
 func (m *Model) openCurrentSelection() {
 	item := m.Items[m.Selection]
-	if len(item.SubMenu) > 0 {
+	items := item.SubMenu
+	if item.SubMenuFunc != nil {
+		items = item.SubMenuFunc()
+	}
+	m.closeSubMenu()
+	if item.List != nil {
+		m.openList(item)
+		return
+	}
+	if item.FilePicker != nil {
+		m.openFilePicker(item)
+		return
+	}
+	if item.Popup != nil {
+		m.openPopup(item)
+		return
+	}
+	if len(items) > 0 {
 		m.OpenSubMenu = m.Selection
-		sub := New(item.SubMenu)
-		sub.isDropdown = true
-		sub.Styles = m.Styles
+		var sub Model
+		if item.Sort != nil || item.GroupBy != nil {
+			arranged, order := arrangeItems(item, items)
+			sub = m.newSubMenu(item, arranged)
+			sub.source, sub.order = items, order
+		} else {
+			sub = m.newSubMenu(item, items)
+		}
+		_, top := m.subMenuOrigin(0, m.top)
+		sub.fitInline(top)
 		m.SubMenuState = &sub
 	}
 }
 
+// closeSubMenu closes the open submenu, releasing its model along with any
+// submenus nested in it.
+func (m *Model) closeSubMenu() {
+	m.rememberOpen()
+	m.OpenSubMenu = -1
+	m.SubMenuState = nil
+}
+
+// newSubMenu builds the model for a dropdown, inheriting this model's
+// configuration (ID, styles, etc.).
+func (m Model) newSubMenu(item MenuItem, items []MenuItem) Model {
+	sub := m
+	sub.Items = items
+	sub.Active = true
+	sub.Selection = 0
+	sub.OpenSubMenu, sub.SubMenuState = -1, nil
+	sub.lastOpen = nil
+	sub.scroll = 0
+	sub.openedAt = time.Now()
+	sub.prompt = false
+	sub.confirmed = false
+	sub.input = nil
+	sub.list = nil
+	sub.picker = nil
+	sub.popup, sub.starts = nil, nil
+	sub.search = nil
+	sub.flashing = false
+	sub.minWidth, sub.width, sub.height = item.MinWidth, item.Width, item.Height
+	sub.isDropdown = true
+	sub.path = m.itemPath(item)
+	sub.data = item.Data
+	sub.source, sub.order = nil, nil
+	sub.itemsGen = m.cache.next()
+	return sub
+}
+
+func (m Model) itemPath(item MenuItem) []string {
+	path := make([]string, 0, len(m.path)+1)
+	return append(append(path, m.path...), item.Label)
+}
+
+func (m *Model) activate(i int) tea.Cmd {
+	switch {
+	case m.prompt:
+		m.answer(i)
+		return nil
+	case m.isDropdown && m.Items[i].Input != nil && (m.input == nil || m.editing != i):
+		return m.startInput(i)
+	case m.Items[i].Confirm != "":
+		m.openConfirm(i)
+		return nil
+	}
+	return m.run(i)
+}
+
+func (m *Model) run(i int) tea.Cmd {
+	var cmds []tea.Cmd
+	item := m.Items[i]
+	switch {
+	case item.RadioGroup != "":
+		for j := range m.Items {
+			if m.Items[j].RadioGroup == item.RadioGroup && m.Items[j].Checked != (i == j) {
+				cmds = append(cmds, m.setChecked(j, i == j))
+			}
+		}
+	case item.Checkable:
+		cmds = append(cmds, m.setChecked(i, item.Mixed || !item.Checked))
+	}
+
+	path := m.itemPath(item)
+	if item.replays != nil {
+		path = item.replays
+	}
+	action := m.itemAction(path, item)
+	if action == nil && item.Input != nil {
+		value := m.inputValue(i)
+		action = func() tea.Msg { return item.Input(value) }
+	}
+	m.input = nil
+	if action != nil {
+		cmds = append(cmds, m.logAction(path, m.wrapAction(action)))
+	}
+	if m.History != nil {
+		m.History.record(path, item.Data, action)
+	}
+	activated := ActivatedMsg{MenuID: m.ID, Path: path, Data: item.Data}
+	cmds = append(cmds, func() tea.Msg { return activated })
+	return tea.Batch(cmds...)
+}
+
+// itemAction returns what activating item runs, reporting as path. Items with
+// an Input have none until a value was entered.
+func (m Model) itemAction(path []string, item MenuItem) func() tea.Msg {
+	switch {
+	case item.ActionErr != nil:
+		return m.reportError(path, item.Data, item.ActionErr)
+	case item.ActionCtx != nil:
+		return m.contextAction(path, item.ActionCtx)
+	case item.Exec != "":
+		return m.execAction(path, item)
+	case item.Dialog != nil:
+		return m.openDialog(item.Dialog)
+	case item.Input != nil:
+		return nil
+	}
+	return item.Action
+}
+
+// reportError adapts an ActionErr, turning its error into an ActionErrorMsg.
+func (m Model) reportError(path []string, data any, action func() (tea.Msg, error)) func() tea.Msg {
+	id := m.ID
+	return func() tea.Msg {
+		msg, err := action()
+		if err != nil {
+			return ActionErrorMsg{MenuID: id, Path: path, Data: data, Err: err}
+		}
+		return msg
+	}
+}
+
+func (m *Model) setChecked(i int, checked bool) tea.Cmd {
+	m.changedItems()
+	m.Items[i].Checked = checked
+	m.Items[i].Mixed = false
+	if m.source != nil {
+		m.source[m.order[i]].Checked = checked
+		m.source[m.order[i]].Mixed = false
+	}
+	if m.Items[i].OnToggle != nil {
+		return m.Items[i].OnToggle(checked)
+	}
+	return nil
+}
+
+// Focused reports whether the menubar is currently taking input.
+func (m Model) Focused() bool {
+	return m.Active
+}
+
+// Owns reports whether msg was emitted by this menubar.
+func (m Model) Owns(msg tea.Msg) bool {
+	if msg, ok := msg.(interface{ menuID() string }); ok {
+		return msg.menuID() == m.ID
+	}
+	return false
+}
+
 func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
-	handled, cmd := m.checkMouse(msg, 0, 0)
+	handled, cmd := m.checkMouse(msg, m.X, m.Y)
 
 	// If click outside, close menus
-	if !handled && msg.Type == tea.MouseRelease {
-		m.Active = false
-		m.OpenSubMenu = -1
-		m.SubMenuState = nil
+	if !handled && msg.Type == tea.MouseRelease && m.Active {
+		switch m.ClickOutside {
+		case CloseAll:
+			m.Active = false
+			m.closeSubMenu()
+		case CloseDropdowns:
+			m.closeSubMenu()
+		}
+		outside := OutsideClickMsg{MenuID: m.ID, X: msg.X, Y: msg.Y}
+		cmd = tea.Batch(cmd, func() tea.Msg { return outside })
 	}
 
 	return m, cmd
@@ -492,37 +1105,10 @@ func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
 func (m *Model) checkMouse(msg tea.MouseMsg, baseX, baseY int) (bool, tea.Cmd) {
 	// 1. Check open submenu first (it's on top)
 	if m.OpenSubMenu != -1 && m.SubMenuState != nil {
-		var subX, subY int
-		if m.isDropdown {
-			// Submenu of a dropdown
-			// Position is to the right of the rendering
-			width, _ := m.getDropdownDimensions()
-			subX = baseX + width
-			topBorder := lipgloss.Height(m.Styles.Dropdown.GetBorderStyle().Top)
-
-			yOffset := topBorder
-			for i := 0; i < m.OpenSubMenu; i++ {
-				h := lipgloss.Height(m.Styles.DropdownItem.Render("A"))
-				if m.Items[i].IsSeparator {
-					h = lipgloss.Height(m.Styles.Separator.Render("-"))
-				}
-				yOffset += h
-			}
-			subY = baseY + yOffset
-		} else {
-			// Submenu of the bar
-			// X = offset of item
-			// Y = 1
-			subX = baseX
-			for i := 0; i < m.OpenSubMenu; i++ {
-				subX += m.measureItem(i)
-			}
-			subY = baseY + lipgloss.Height(m.Styles.Bar.Render("A")) // Height of bar
-		}
-
+		subX, subY := m.subMenuOrigin(baseX, baseY)
 		handled, cmd := m.SubMenuState.checkMouse(msg, subX, subY)
 		if handled {
-			return true, cmd
+			return true, tea.Batch(cmd, m.settleSubMenu())
 		}
 	}
 
@@ -536,30 +1122,58 @@ func (m *Model) checkMouse(msg tea.MouseMsg, baseX, baseY int) (bool, tea.Cmd) {
 			topBorder := lipgloss.Height(m.Styles.Dropdown.GetBorderStyle().Top)
 			localY := msg.Y - baseY - topBorder
 
-			// We iterate items to find which one covers localY
-			currentY := 0
-			for i := range m.Items {
-				itemH := lipgloss.Height(m.Styles.DropdownItem.Render("A"))
-				if m.Items[i].IsSeparator {
-					itemH = lipgloss.Height(m.Styles.Separator.Render("-"))
+			if m.list != nil {
+				switch msg.Type {
+				case tea.MouseWheelUp:
+					m.list.CursorUp()
+				case tea.MouseWheelDown:
+					m.list.CursorDown()
 				}
+				return true, nil
+			}
+			if m.popup != nil {
+				return true, m.popupMouse(msg, baseX, baseY)
+			}
+
+			switch msg.Type {
+			case tea.MouseWheelUp:
+				m.scrollBy(-1)
+				return true, nil
+			case tea.MouseWheelDown:
+				m.scrollBy(1)
+				return true, nil
+			}
+
+			// We iterate visible rows to find which one covers localY
+			currentY := 0
+			rows, heights := m.layoutRows()
+			for j, i := range rows {
+				itemH := heights[j]
 
 				if localY >= currentY && localY < currentY+itemH {
+					if i == scrollUpRow || i == scrollDownRow {
+						// Clicking a scroll indicator scrolls
+						if msg.Type == tea.MouseRelease && i == scrollUpRow {
+							m.scrollBy(-1)
+						} else if msg.Type == tea.MouseRelease {
+							m.scrollBy(1)
+						}
+						return true, nil
+					}
 					if m.Items[i].IsSeparator || m.Items[i].Disabled {
 						return true, nil
 					}
 					m.Selection = i
 
 					if msg.Type == tea.MouseRelease {
-						if len(m.Items[i].SubMenu) > 0 {
+						if m.Items[i].hasSubMenu() {
 							m.openCurrentSelection()
-						} else if m.Items[i].Action != nil {
-							return true, func() tea.Msg { return m.Items[i].Action() }
+						} else {
+							return true, m.activate(i)
 						}
 					} else if msg.Type == tea.MouseMotion {
 						if m.OpenSubMenu != -1 && m.OpenSubMenu != i {
-							m.OpenSubMenu = -1
-							m.SubMenuState = nil
+							m.closeSubMenu()
 						}
 					}
 					return true, nil
@@ -569,11 +1183,10 @@ func (m *Model) checkMouse(msg tea.MouseMsg, baseX, baseY int) (bool, tea.Cmd) {
 			return true, nil
 		}
 	} else {
-		barHeight := lipgloss.Height(m.Styles.Bar.Render("A"))
+		barHeight := m.BarHeight()
 		if msg.Y >= baseY && msg.Y < baseY+barHeight {
-			currentX := baseX
-			for i := range m.Items {
-				w := m.measureItem(i)
+			for i, x := range m.barLayout(m.cache.barSize()) {
+				currentX, w := baseX+x, m.measureItem(i)
 				if msg.X >= currentX && msg.X < currentX+w {
 					m.Selection = i
 
@@ -581,15 +1194,14 @@ func (m *Model) checkMouse(msg tea.MouseMsg, baseX, baseY int) (bool, tea.Cmd) {
 						if !m.Active {
 							m.Active = true
 						}
-						if len(m.Items[i].SubMenu) > 0 {
+						if m.Items[i].hasSubMenu() {
 							if m.OpenSubMenu == i {
-								m.OpenSubMenu = -1
-								m.SubMenuState = nil
+								m.closeSubMenu()
 							} else {
 								m.openCurrentSelection()
 							}
-						} else if m.Items[i].Action != nil {
-							return true, func() tea.Msg { return m.Items[i].Action() }
+						} else {
+							return true, m.activate(i)
 						}
 					} else if msg.Type == tea.MouseMotion {
 						if m.Active && m.OpenSubMenu != -1 && m.OpenSubMenu != i {
@@ -607,6 +1219,31 @@ func (m *Model) checkMouse(msg tea.MouseMsg, baseX, baseY int) (bool, tea.Cmd) {
 	return false, nil
 }
 
+// subMenuOrigin returns the screen position of the open submenu, given the
+// screen position of this menu.
+func (m Model) subMenuOrigin(baseX, baseY int) (int, int) {
+	if m.isDropdown {
+		// Submenu of a dropdown
+		// Position is to the right of the rendering
+		width, _ := m.getDropdownDimensions()
+		yOffset := lipgloss.Height(m.Styles.Dropdown.GetBorderStyle().Top) + m.rowOffset(m.OpenSubMenu)
+		return baseX + width, baseY + yOffset
+	}
+
+	// Submenu of the bar
+	// X = offset of item
+	// Y = height of bar
+	return baseX + m.barItemX(m.OpenSubMenu), baseY + m.BarHeight()
+}
+
+// description returns the second line of dropdown row i, if it has one.
+func (m Model) description(i int) string {
+	if !m.ShowDescriptions || m.Items[i].IsSeparator {
+		return ""
+	}
+	return m.tr(m.Items[i].Description)
+}
+
 func (m Model) hasOpenSubmenu() bool {
 	return m.OpenSubMenu != -1 && m.SubMenuState != nil
 }
@@ -615,71 +1252,183 @@ func (m Model) wantsToHandleRight() bool {
 	if m.OpenSubMenu != -1 && m.SubMenuState != nil {
 		return m.SubMenuState.wantsToHandleRight()
 	}
-	return len(m.Items) > 0 && len(m.Items[m.Selection].SubMenu) > 0
+	return len(m.Items) > 0 && m.Items[m.Selection].hasSubMenu()
 }
 
 func (m Model) getLayersRecursive(baseX, baseY int) []DropdownLayer {
-	currentView := m.renderSingleDropdown()
+	currentView := m.viewSingleDropdown()
 	layers := []DropdownLayer{{Content: currentView, X: baseX, Y: baseY}}
 
 	if m.OpenSubMenu != -1 && m.SubMenuState != nil {
 		menuWidth := lipgloss.Width(currentView)
-		// Assuming 1 line for top border + selection index
-		yOffset := m.Selection + 1
-		subLayers := m.SubMenuState.getLayersRecursive(baseX+menuWidth, baseY+yOffset)
+		_, y := m.subMenuOrigin(baseX, baseY)
+		subLayers := m.SubMenuState.getLayersRecursive(baseX+menuWidth, y)
 		layers = append(layers, subLayers...)
 	}
 	return layers
 }
 
 func (m Model) measureItem(i int) int {
-	if m.Items[i].IsSeparator {
-		return lipgloss.Width(m.Styles.Item.Render("|"))
+	return m.barItemWidths()[i]
+}
+
+// barItemWidths returns the rendered width of every bar item, cached until the
+// items, styles or selection change.
+func (m Model) barItemWidths() []int {
+	key := m.fingerprint("widths")
+	if widths, ok := m.cache.getWidths(key); ok {
+		return widths
+	}
+	widths := make([]int, len(m.Items))
+	widest := 0
+	for i := range m.Items {
+		widths[i] = lipgloss.Width(m.renderBarLabel(i))
+		if !m.Items[i].IsSeparator && widths[i] > widest {
+			widest = widths[i]
+		}
+	}
+	if m.UniformWidth {
+		for i := range m.Items {
+			if !m.Items[i].IsSeparator {
+				widths[i] = widest
+			}
+		}
+	}
+	m.cache.setWidths(key, widths)
+	return widths
+}
+
+func (m Model) renderBarItem(i int) string {
+	view := m.renderBarLabel(i)
+	if !m.UniformWidth || m.Items[i].IsSeparator {
+		return view
 	}
-	style := m.Styles.Item
+	// Center the label in the uniform width
+	st := m.barItemStyles(i)
+	pad := m.barItemWidths()[i] - lipgloss.Width(view)
+	return st.base.Render(strings.Repeat(" ", pad/2)) + view + st.base.Render(strings.Repeat(" ", pad-pad/2))
+}
 
+// renderBarLabel renders bar item i at its natural width.
+func (m Model) renderBarLabel(i int) string {
+	if m.Items[i].IsSeparator {
+		return m.derived().barSeparator.Render(m.Glyphs.BarSeparator)
+	}
+	st := m.barItemStyles(i)
+	item := m.Items[i]
+	item.Label = m.tr(item.label())
+	label := m.renderLabel(item, st)
+	if item.Modified {
+		label += st.base.Render(" " + m.Glyphs.Modified)
+	}
+	outer := st.outer
 	if m.Active && i == m.Selection {
-		style = m.Styles.SelectedItem
+		outer, label = m.marked(st, label)
+	}
+	return outer.Render(label)
+}
+
+// SetModified marks or unmarks the top-level item with the given label.
+func (m *Model) SetModified(label string, modified bool) {
+	m.touch()
+	if i := m.indexOfLabel(label); i != -1 {
+		m.changedItems()
+		m.Items[i].Modified = modified
+	}
+}
+
+// SetValue sets the Value of the item at path, the labels leading to it from
+// the bar, e.g. []string{"View", "Theme"}. Paths that don't exist are ignored.
+func (m *Model) SetValue(path []string, value string) {
+	m.touch()
+	if item := itemAt(m.Items, path); item != nil {
+		m.changedItems()
+		item.Value = value
 	}
-	baseStyle := style.Copy().UnsetPadding()
-	rendered := style.Render(m.renderLabel(m.Items[i], baseStyle))
-	return lipgloss.Width(rendered)
 }
 
 func (m Model) renderBarContent(right string, width int) string {
+	if width <= 0 {
+		width = m.sizedWidth
+	}
+	if m.message != "" {
+		right = m.derived().barMessage.Render(m.message)
+	}
+	if m.BeforeRender != nil {
+		m.BeforeRender(nil)
+	}
+	bar := m.renderBar(joinRight(m.chordView(), m.spinnerView(), m.progressView(), right, m.widgetsView()), width)
+	if m.OnRenderBar != nil {
+		bar = m.OnRenderBar(bar)
+	}
+	return bar
+}
+
+func (m Model) renderBar(right string, width int) string {
+	if m.collapsesAt(width) {
+		return m.hamburger().renderBar(right, width)
+	}
+	m.cache.setBarSize(m.rightWidth(right), width)
+	key := m.fingerprint("bar", right, width)
+	return m.cache.get(key, func() string { return m.buildBarContent(right, width) })
+}
+
+// rightWidth returns the width of the bar's right side content once styled.
+func (m Model) rightWidth(right string) int {
+	if right == "" {
+		return 0
+	}
+	return lipgloss.Width(m.derived().barRight.Render(right))
+}
+
+func (m Model) buildBarContent(right string, width int) string {
+	d := m.derived()
+	if right != "" {
+		right = d.barRight.Render(right)
+	}
+	rightWidth := lipgloss.Width(right)
+
 	var views []string
-	for i, item := range m.Items {
-		style := m.Styles.Item
-		if item.IsSeparator {
-			style = m.Styles.Disabled.Copy().Inherit(style).UnsetPadding()
-			views = append(views, style.Render("|"))
-			continue
+	var painted []bool // Whether each view gets the gradient
+	x := 0
+	for i, itemX := range m.barLayout(rightWidth, width) {
+		if itemX > x {
+			views = append(views, d.barSpacer.Render(strings.Repeat(" ", itemX-x)))
+			painted = append(painted, true)
 		}
-		if m.Active && i == m.Selection {
-			style = m.Styles.SelectedItem
-		}
-		if item.Disabled {
-			style = m.Styles.Disabled.Copy().Inherit(style)
-		}
-		baseStyle := style.Copy().UnsetPadding()
-		views = append(views, style.Render(m.renderLabel(item, baseStyle)))
+		view := m.renderBarItem(i)
+		views = append(views, view)
+		painted = append(painted, !(m.Active && i == m.Selection || m.flashed(i)))
+		x = itemX + lipgloss.Width(view)
 	}
 
-	fillStyle := m.Styles.Bar.Copy().UnsetPadding().BorderTop(false).BorderRight(false).BorderBottom(false).BorderLeft(false).Margin(0)
-
 	if width > 0 {
-		itemsWidth := lipgloss.Width(lipgloss.JoinHorizontal(lipgloss.Top, views...))
-		rightWidth := lipgloss.Width(right)
 		availableWidth := width - m.Styles.Bar.GetHorizontalFrameSize()
-		spacerWidth := availableWidth - itemsWidth - rightWidth
+		spacerWidth := availableWidth - x - rightWidth
 
 		if spacerWidth > 0 {
-			views = append(views, fillStyle.Render(strings.Repeat(" ", spacerWidth)))
+			views = append(views, d.barSpacer.Render(strings.Repeat(" ", spacerWidth)))
+			painted = append(painted, true)
 		}
 	}
 
 	if right != "" {
-		views = append(views, fillStyle.Render(right))
+		views = append(views, right)
+		painted = append(painted, true)
+	}
+
+	if len(m.Styles.BarGradient) > 1 {
+		total := 0
+		for _, view := range views {
+			total += lipgloss.Width(view)
+		}
+		x = 0
+		for i, view := range views {
+			if painted[i] {
+				views[i] = m.paintGradient(view, x, total)
+			}
+			x += lipgloss.Width(view)
+		}
 	}
 
 	return m.Styles.Bar.Render(lipgloss.JoinHorizontal(lipgloss.Top, views...))
@@ -689,92 +1438,209 @@ func (m Model) getDropdownOffset() int {
 	if m.OpenSubMenu == -1 {
 		return 0
 	}
-	offset := 0
-	for i := 0; i < m.OpenSubMenu; i++ {
-		baseStyle := m.Styles.Item.Copy().UnsetPadding()
-		offset += lipgloss.Width(m.Styles.Item.Render(m.renderLabel(m.Items[i], baseStyle)))
-	}
-	return offset
+	return m.barItemX(m.OpenSubMenu)
 }
 
 func (m Model) viewDropdown() string {
-	menu := m.renderSingleDropdown()
+	menu := m.viewSingleDropdown()
 
 	if m.OpenSubMenu != -1 && m.SubMenuState != nil {
 		subMenu := m.SubMenuState.View()
-		padding := strings.Repeat("\n", m.Selection+1) // +1 for top border
+		padding := strings.Repeat("\n", m.rowOffset(m.Selection)+1) // +1 for top border
 		return lipgloss.JoinHorizontal(lipgloss.Top, menu, padding+subMenu)
 	}
 
 	return menu
 }
 
-func (m Model) getDropdownDimensions() (int, int) {
-	maxLabelWidth := 0
-	maxShortcutWidth := 0
+type dropdownLayout struct {
+	labelWidth int // Widest label
+	valueWidth int // Widest value, 0 if no item has one
+	rightWidth int // Widest shortcut or submenu indicator
+	checkWidth int // Width of the check column, 0 if nothing is checkable
+	gap        int // Columns between the labels and the right column
+}
+
+func (l dropdownLayout) contentWidth() int {
+	return l.checkWidth + l.labelWidth + l.gap + l.valueColumnWidth() + l.rightWidth
+}
+
+// valueColumnWidth returns the width of the value column, including the gap
+// separating it from the right column.
+func (l dropdownLayout) valueColumnWidth() int {
+	if l.valueWidth == 0 {
+		return 0
+	}
+	if l.rightWidth == 0 {
+		return l.valueWidth
+	}
+	return l.valueWidth + l.gap
+}
+
+func (m Model) layoutDropdown() dropdownLayout {
+	l := dropdownLayout{gap: m.ShortcutGap}
 	hasSubmenu := false
 
 	for _, item := range m.Items {
-		w := lipgloss.Width(item.Label)
-		if w > maxLabelWidth {
-			maxLabelWidth = w
+		w := lipgloss.Width(m.tr(item.label()))
+		if item.Input != nil {
+			w += 1 + inputMinWidth
+		}
+		if w > l.labelWidth {
+			l.labelWidth = w
+		}
+		if vw := lipgloss.Width(item.Value); vw > l.valueWidth {
+			l.valueWidth = vw
 		}
 		sw := lipgloss.Width(item.Shortcut)
-		if sw > maxShortcutWidth {
-			maxShortcutWidth = sw
+		if sw > l.rightWidth && !m.HideShortcuts {
+			l.rightWidth = sw
 		}
-		if len(item.SubMenu) > 0 {
+		if item.hasSubMenu() {
 			hasSubmenu = true
 		}
+		if item.Checkable || item.Checked || item.Mixed || item.RadioGroup != "" {
+			for _, glyph := range []string{m.Glyphs.Check, m.Glyphs.Radio, m.Glyphs.Mixed} {
+				if w := lipgloss.Width(glyph) + 1; w > l.checkWidth {
+					l.checkWidth = w
+				}
+			}
+		}
+	}
+
+	if w := lipgloss.Width(m.Glyphs.SubMenu) + 1; hasSubmenu && l.rightWidth < w {
+		l.rightWidth = w
+	}
+
+	// Descriptions span every column after the check column
+	for i := range m.Items {
+		if w := lipgloss.Width(m.description(i)) - (l.contentWidth() - l.checkWidth); w > 0 {
+			l.labelWidth += w
+		}
 	}
 
-	maxRightWidth := maxShortcutWidth
-	if hasSubmenu && maxRightWidth < 2 {
-		maxRightWidth = 2
+	frame := m.Styles.Dropdown.GetHorizontalFrameSize() + m.Styles.DropdownItem.GetHorizontalFrameSize()
+	switch {
+	case m.width > 0:
+		l.labelWidth += m.width - frame - l.contentWidth()
+	case m.minWidth > 0 && l.contentWidth() < m.minWidth-frame:
+		l.labelWidth += m.minWidth - frame - l.contentWidth()
+	}
+	if l.labelWidth < 0 {
+		l.labelWidth = 0
 	}
+	return l
+}
 
-	dummyStyle := m.Styles.DropdownItem
-	innerContentWidth := maxLabelWidth + 2 + maxRightWidth
+func (m Model) getDropdownDimensions() (int, int) {
+	if m.list != nil || m.picker != nil || m.popup != nil {
+		view := m.renderSingleDropdown()
+		return lipgloss.Width(view), lipgloss.Height(view)
+	}
+	innerContentWidth := m.layoutDropdown().contentWidth()
 
-	itemWidth := lipgloss.Width(dummyStyle.Render(strings.Repeat(" ", innerContentWidth)))
-	height := len(m.Items)
+	itemWidth := lipgloss.Width(m.Styles.DropdownItem.Render(strings.Repeat(" ", innerContentWidth)))
+	height := 0
+	_, heights := m.layoutRows()
+	for _, h := range heights {
+		height += h
+	}
 
 	w, h := m.Styles.Dropdown.GetFrameSize()
 
 	return itemWidth + w, height + h
 }
 
+// viewSingleDropdown renders this dropdown through the render hooks.
+func (m Model) viewSingleDropdown() string {
+	if m.BeforeRender != nil {
+		m.BeforeRender(m.path)
+	}
+	view := m.renderSingleDropdown()
+	if m.OnRenderDropdown != nil {
+		view = m.OnRenderDropdown(m.path, view)
+	}
+	return view
+}
+
 func (m Model) renderSingleDropdown() string {
-	// Calculate widths for alignment
-	maxLabelWidth := 0
-	maxShortcutWidth := 0
-	hasSubmenu := false
+	if m.list != nil {
+		return m.Styles.Dropdown.Render(m.list.View())
+	}
+	if m.picker != nil {
+		return m.renderFilePicker()
+	}
+	if m.popup != nil {
+		return m.Styles.Dropdown.Render(m.popup.View())
+	}
+	return m.cache.get(m.dropdownKey(), m.buildSingleDropdown)
+}
 
-	for _, item := range m.Items {
-		w := lipgloss.Width(item.Label)
-		if w > maxLabelWidth {
-			maxLabelWidth = w
-		}
-		sw := lipgloss.Width(item.Shortcut)
-		if sw > maxShortcutWidth {
-			maxShortcutWidth = sw
-		}
-		if len(item.SubMenu) > 0 {
-			hasSubmenu = true
+func (m Model) dropdownKey() uint64 {
+	var input string
+	if m.input != nil {
+		input = m.input.View()
+	}
+	return m.fingerprint("dropdown", m.editing, input)
+}
+
+func (m Model) buildSingleDropdown() string {
+	rows := m.dropdownRows()
+	m.cache.setWidths(m.dropdownKey(), measureHeights(rows))
+	if m.scrollable() {
+		width := lipgloss.Width(m.Styles.DropdownItem.Render(strings.Repeat(" ", m.layoutDropdown().contentWidth())))
+		layout, _ := m.layoutRows()
+		views := make([]string, 0, len(layout))
+		for _, i := range layout {
+			switch i {
+			case scrollUpRow:
+				views = append(views, m.scrollIndicator(width, -1))
+			case scrollDownRow:
+				views = append(views, m.scrollIndicator(width, 1))
+			default:
+				views, rows = append(views, rows[0]), rows[1:]
+			}
 		}
+		rows = views
+	}
+	return m.Styles.Dropdown.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// rowHeights returns the height of every visible dropdown item as rendered,
+// in the order of visibleItems.
+func (m Model) rowHeights() []int {
+	key := m.dropdownKey()
+	if heights, ok := m.cache.getWidths(key); ok {
+		return heights
 	}
+	heights := measureHeights(m.dropdownRows())
+	m.cache.setWidths(key, heights)
+	return heights
+}
 
-	maxRightWidth := maxShortcutWidth
-	if hasSubmenu && maxRightWidth < 2 {
-		maxRightWidth = 2
+func measureHeights(rows []string) []int {
+	heights := make([]int, len(rows))
+	for i, row := range rows {
+		heights[i] = lipgloss.Height(row)
 	}
+	return heights
+}
+
+// dropdownRows renders the visible rows of the dropdown, without its frame.
+func (m Model) dropdownRows() []string {
+	d := m.derived()
+
+	// Calculate widths for alignment
+	layout := m.layoutDropdown()
+	maxLabelWidth := layout.labelWidth
+	maxRightWidth := layout.rightWidth
 
 	// Calculate standard item width (including padding)
-	innerContentWidth := maxLabelWidth + 2 + maxRightWidth
-	standardWidth := lipgloss.Width(m.Styles.DropdownItem.Render(strings.Repeat(" ", innerContentWidth)))
+	standardWidth := lipgloss.Width(m.Styles.DropdownItem.Render(strings.Repeat(" ", layout.contentWidth())))
 
 	var views []string
-	for i, item := range m.Items {
+	for _, i := range m.visibleItems() {
+		item := m.Items[i]
 		if item.IsSeparator {
 			// Calculate line length to match standardWidth when rendered with separator style
 			separatorSideWidth := m.Styles.Separator.GetHorizontalFrameSize()
@@ -782,105 +1648,149 @@ func (m Model) renderSingleDropdown() string {
 			if lineLength < 0 {
 				lineLength = 0
 			}
-			line := strings.Repeat("─", lineLength)
+			line := ""
+			if w := lipgloss.Width(m.Glyphs.Separator); w > 0 {
+				line = strings.Repeat(m.Glyphs.Separator, lineLength/w)
+				line += strings.Repeat(" ", lineLength-lipgloss.Width(line))
+			}
 			views = append(views, m.Styles.Separator.Render(line))
 			continue
 		}
 
-		style := m.Styles.DropdownItem
-		if i == m.Selection {
-			style = m.Styles.DropdownSelected
+		st := d.dropdownItem
+		if i == m.Selection || m.flashed(i) {
+			st = d.dropdownSelected
 		}
 		if item.Disabled {
-			style = m.Styles.Disabled.Copy().Inherit(m.Styles.DropdownItem)
+			st = d.dropdownDisabled
+		}
+		if item.header {
+			st = d.dropdownHeader
+		}
+		baseStyle := st.base
+
+		// Render Check column
+		check := ""
+		if layout.checkWidth > 0 {
+			mark := ""
+			if item.Mixed {
+				mark = m.Glyphs.Mixed
+			} else if item.Checked && item.RadioGroup != "" {
+				mark = m.Glyphs.Radio
+			} else if item.Checked {
+				mark = m.Glyphs.Check
+			}
+			check = baseStyle.Render(mark + strings.Repeat(" ", layout.checkWidth-lipgloss.Width(mark)))
 		}
-
-		baseStyle := style.Copy().UnsetPadding()
 
 		// Render Label
-		label := m.renderLabel(item, baseStyle)
+		item.Label = m.tr(item.label())
+		if lipgloss.Width(item.Label) > maxLabelWidth {
+			item.Label = truncate(item.Label, maxLabelWidth, m.Glyphs.Ellipsis)
+		}
+		label := m.renderLabel(item, st)
 		currentLabelWidth := lipgloss.Width(label)
 
+		if m.input != nil && i == m.editing {
+			input := m.renderInput(st, layout.contentWidth()-layout.checkWidth-currentLabelWidth-1)
+			outer, line := m.marked(st, check+label+baseStyle.Render(" ")+input)
+			views = append(views, outer.Render(line))
+			continue
+		}
+
 		// Pad label to max width + gap
-		padding := baseStyle.Render(strings.Repeat(" ", maxLabelWidth-currentLabelWidth+2))
+		padding := baseStyle.Render(strings.Repeat(" ", maxLabelWidth-currentLabelWidth+layout.gap))
 
 		// Right-side content (Shortcut or Submenu Indicator)
 		rightContent := ""
-		if item.Shortcut != "" {
-			shortcutStyle := m.Styles.Shortcut.Copy().Inherit(baseStyle)
-			if i == m.Selection {
-				shortcutStyle = m.Styles.ShortcutSelected.Copy().Inherit(baseStyle)
-			}
-			if item.Disabled {
-				shortcutStyle = m.Styles.Disabled.Copy().Inherit(baseStyle).Padding(0)
+		if item.Shortcut != "" && !m.HideShortcuts {
+			shortcutStr := st.shortcut.Render(item.Shortcut)
+			fill := baseStyle.Render(strings.Repeat(" ", maxRightWidth-lipgloss.Width(item.Shortcut)))
+			if m.ShortcutAlign == lipgloss.Left {
+				rightContent = shortcutStr + fill
+			} else {
+				rightContent = fill + shortcutStr
 			}
-
-			shortcutStr := shortcutStyle.Render(item.Shortcut)
-			// Right align shortcut in the right column
-			rightContent = baseStyle.Render(strings.Repeat(" ", maxRightWidth-lipgloss.Width(item.Shortcut))) + shortcutStr
-		} else if len(item.SubMenu) > 0 {
+		} else if item.hasSubMenu() {
 			// Right align indicator in the right column
-			rightContent = baseStyle.Render(strings.Repeat(" ", maxRightWidth-2) + " >")
+			rightContent = baseStyle.Render(strings.Repeat(" ", maxRightWidth-lipgloss.Width(m.Glyphs.SubMenu)) + m.Glyphs.SubMenu)
 		} else if maxRightWidth > 0 {
 			// Empty space for items with neither
 			rightContent = baseStyle.Render(strings.Repeat(" ", maxRightWidth))
 		}
 
-		// Combine: Label + Padding + RightContent
-		line := label + padding + rightContent
-		views = append(views, style.Render(line))
+		// Value column, left aligned
+		value := ""
+		if layout.valueWidth > 0 {
+			value = st.shortcut.Render(item.Value) + baseStyle.Render(strings.Repeat(" ", layout.valueColumnWidth()-lipgloss.Width(item.Value)))
+		}
+
+		// Combine: Check + Label + Padding + Value + RightContent
+		line := check + label + padding + value + rightContent
+		if desc := m.description(i); desc != "" {
+			width := layout.contentWidth() - layout.checkWidth
+			if lipgloss.Width(desc) > width {
+				desc = truncate(desc, width, m.Glyphs.Ellipsis)
+			}
+			desc = renderSpans(st.description, desc) + baseStyle.Render(strings.Repeat(" ", width-lipgloss.Width(desc)))
+			line += "\n" + baseStyle.Render(strings.Repeat(" ", layout.checkWidth)) + desc
+		}
+		outer := st.outer
+		if i == m.Selection {
+			outer, line = m.marked(st, line)
+		}
+		views = append(views, outer.Render(line))
 	}
 
-	return m.Styles.Dropdown.Render(lipgloss.JoinVertical(lipgloss.Left, views...))
+	return views
 }
 
-func (m Model) renderLabel(item MenuItem, baseStyle lipgloss.Style) string {
+func (m Model) renderLabel(item MenuItem, st itemStyles) string {
 	if item.Hotkey == "" || item.Disabled {
-		return baseStyle.Render(item.Label)
+		return renderSpans(st.base, item.Label)
 	}
 
-	idx := strings.Index(item.Label, item.Hotkey)
+	text := stripANSI(item.Label)
+	idx := strings.Index(text, item.Hotkey)
 	if idx == -1 {
-		idx = strings.Index(strings.ToLower(item.Label), strings.ToLower(item.Hotkey))
+		idx = strings.Index(strings.ToLower(text), strings.ToLower(item.Hotkey))
 	}
 
 	if idx == -1 {
-		return baseStyle.Render(item.Label)
+		return renderSpans(st.base, item.Label)
 	}
 
-	pre := item.Label[:idx]
-	hot := item.Label[idx : idx+len(item.Hotkey)]
-	post := item.Label[idx+len(item.Hotkey):]
-
-	hotStyle := m.Styles.Hotkey.Copy().Inherit(baseStyle)
+	pre, hot, post := cutVisible(item.Label, idx, idx+len(item.Hotkey))
 
 	var postRendered string
 	if post != "" {
-		postRendered = baseStyle.Inline(true).Render(post)
+		postRendered = renderSpans(st.inline, post)
 	}
 
-	return baseStyle.Render(pre) + hotStyle.Render(hot) + postRendered
+	return renderSpans(st.base, pre) + renderSpans(st.hotkey, hot) + postRendered
 }
 
-func splitWithANSI(s string, width int) (string, string) {
-	prevI := 0
-	for i := range s {
-		w := lipgloss.Width(s[:i])
-		if w == width {
-			return s[:i], s[i:]
-		}
-		if w > width {
-			// We exceeded the target width (e.g., wide character).
-			// We split before this character.
-			return s[:prevI], s[prevI:]
+// truncate shortens s to width cells, ending it with ellipsis.
+func truncate(s string, width int, ellipsis string) string {
+	if width <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		n := 0
+		if loc := ansiRegex.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			n = loc[1]
+		} else {
+			_, n = utf8.DecodeRuneInString(s[i:])
+			if lipgloss.Width(b.String()+s[i:i+n])+lipgloss.Width(ellipsis) > width {
+				break
+			}
 		}
-		prevI = i
+		b.WriteString(s[i : i+n])
+		i += n
 	}
-
-	if lipgloss.Width(s) <= width {
-		return s, ""
+	if openSpans(b.String()) != "" {
+		b.WriteString("\x1b[0m")
 	}
-
-	// Should not be reachable if loop covers everything, but safety fallback
-	return s, ""
+	return b.String() + ellipsis
 }
@@ -0,0 +1,174 @@
+// Package menubartest provides helpers for testing applications that use the
+// menubar component, without hand-constructing tea.KeyMsg and tea.MouseMsg
+// values.
+package menubartest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	menubar "github.com/jejacks0n/bubbletea-menubar"
+)
+
+var update = flag.Bool("menubartest.update", false, "update golden files")
+
+var keyTypes = map[string]tea.KeyType{}
+
+func init() {
+	// Named keys run from the special keys, negative, up to the control
+	// characters ending with backspace
+	for k := tea.KeyType(-100); k <= tea.KeyBackspace; k++ {
+		if name := k.String(); name != "" && k != tea.KeyRunes {
+			keyTypes[name] = k
+		}
+	}
+}
+
+// Key converts a key name as produced by tea.KeyMsg.String, like "down",
+// "ctrl+c", "alt+f" or "x", into a tea.KeyMsg.
+func Key(name string) tea.KeyMsg {
+	if t, ok := keyTypes[name]; ok {
+		return tea.KeyMsg{Type: t}
+	}
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok {
+		msg := Key(rest)
+		msg.Alt = true
+		return msg
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
+
+// Press sends each key to the model in order, returning the updated model and
+// the messages produced by the resulting commands, see Messages.
+func Press(m menubar.Model, keys ...string) (menubar.Model, []tea.Msg) {
+	var msgs []tea.Msg
+	for _, key := range keys {
+		var cmd tea.Cmd
+		m, cmd = m.Update(Key(key))
+		msgs = append(msgs, Messages(cmd)...)
+	}
+	return m, msgs
+}
+
+// Click sends a left click (press and release) at the given screen position.
+func Click(m menubar.Model, x, y int) (menubar.Model, []tea.Msg) {
+	var msgs []tea.Msg
+	for _, t := range []tea.MouseEventType{tea.MouseLeft, tea.MouseRelease} {
+		var cmd tea.Cmd
+		m, cmd = m.Update(tea.MouseMsg{X: x, Y: y, Type: t})
+		msgs = append(msgs, Messages(cmd)...)
+	}
+	return m, msgs
+}
+
+// Hover moves the mouse to the given screen position.
+func Hover(m menubar.Model, x, y int) (menubar.Model, []tea.Msg) {
+	m, cmd := m.Update(tea.MouseMsg{X: x, Y: y, Type: tea.MouseMotion})
+	return m, Messages(cmd)
+}
+
+// OpenPath activates the bar and opens the submenus with the given labels, one
+// level at a time. It returns an error naming the first label it couldn't open.
+func OpenPath(m menubar.Model, labels ...string) (menubar.Model, error) {
+	m.RestoreState(menubar.ModelState{Active: true, Open: labels, Checked: m.State().Checked})
+	if open := m.State().Open; len(open) < len(labels) {
+		return m, fmt.Errorf("menubartest: couldn't open %q", strings.Join(labels[:len(open)+1], " > "))
+	}
+	return m, nil
+}
+
+// Timeout bounds how long Messages waits for the messages of a command.
+// Commands still running by then, like the timers ending a pending chord, a
+// flash or a toast, are dropped so tests don't wait on them. Send the messages
+// of the timers yourself to test what happens once they fire.
+var Timeout = 100 * time.Millisecond
+
+// Messages runs a command and returns the messages it produces within Timeout,
+// flattening batched commands, which run concurrently as in a program.
+func Messages(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	return messages(ctx, start(cmd))
+}
+
+// start runs cmd in the background, sending its message on the returned
+// channel.
+func start(cmd tea.Cmd) <-chan tea.Msg {
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	return done
+}
+
+func messages(ctx context.Context, done <-chan tea.Msg) []tea.Msg {
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	default:
+		select {
+		case msg = <-done:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	switch msg := msg.(type) {
+	case nil:
+		return nil
+	case tea.BatchMsg:
+		pending := make([]<-chan tea.Msg, 0, len(msg))
+		for _, cmd := range msg {
+			if cmd != nil {
+				pending = append(pending, start(cmd))
+			}
+		}
+		var msgs []tea.Msg
+		for _, done := range pending {
+			msgs = append(msgs, messages(ctx, done)...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
+
+// View renders the bar at the given width, at its X and Y, with every open
+// dropdown, dialog and toast overlaid on it, the way an application composes
+// the layers of ViewLayers.
+func View(m menubar.Model, width int) string {
+	var view string
+	for _, layer := range m.ViewLayers("", width) {
+		view = menubar.Overlay(view, layer.Content, layer.X, layer.Y)
+	}
+	return view
+}
+
+// AssertGolden compares got with testdata/<name>.golden, failing the test on a
+// mismatch. Run the tests with -menubartest.update to write the golden files.
+func AssertGolden(t testing.TB, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("menubartest: %v (run with -menubartest.update to create it)", err)
+	}
+	if string(want) != got {
+		t.Errorf("menubartest: render doesn't match %s\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
@@ -0,0 +1,124 @@
+package menubartest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	menubar "github.com/jejacks0n/bubbletea-menubar"
+)
+
+func newModel() menubar.Model {
+	m := menubar.New([]menubar.MenuItem{
+		{Label: "File", SubMenu: []menubar.MenuItem{
+			{Label: "Open"},
+			{Label: "Recent", SubMenu: []menubar.MenuItem{{Label: "notes.txt"}}},
+		}},
+		{Label: "Edit", SubMenu: []menubar.MenuItem{{Label: "Undo"}}},
+	})
+	m.UsePlain()
+	return m
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want tea.KeyMsg
+	}{
+		{"down", tea.KeyMsg{Type: tea.KeyDown}},
+		{"ctrl+c", tea.KeyMsg{Type: tea.KeyCtrlC}},
+		{"alt+f", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f"), Alt: true}},
+		{"x", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}},
+	}
+	for _, tt := range tests {
+		got := Key(tt.name)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Key(%q) = %#v, want %#v", tt.name, got, tt.want)
+		}
+		if got.String() != tt.name {
+			t.Errorf("Key(%q).String() = %q", tt.name, got.String())
+		}
+	}
+}
+
+func activated(msgs []tea.Msg) []string {
+	for _, msg := range msgs {
+		if msg, ok := msg.(menubar.ActivatedMsg); ok {
+			return msg.Path
+		}
+	}
+	return nil
+}
+
+func TestPress(t *testing.T) {
+	_, msgs := Press(newModel(), "down", "enter")
+	if got := activated(msgs); !reflect.DeepEqual(got, []string{"File", "Open"}) {
+		t.Errorf("expected File > Open to be activated, got %v", got)
+	}
+}
+
+func TestPressPendingChord(t *testing.T) {
+	m := newModel()
+	m.Items[1].SubMenu[0].Keys = "ctrl+k ctrl+z"
+	start := time.Now()
+	m, msgs := Press(m, "ctrl+k")
+	if elapsed := time.Since(start); elapsed >= m.ChordTimeout {
+		t.Errorf("expected Press to return before the chord times out, took %v", elapsed)
+	}
+	if len(msgs) != 0 {
+		t.Errorf("expected the chord's timer to be dropped, got %v", msgs)
+	}
+	_, msgs = Press(m, "ctrl+z")
+	if got := activated(msgs); !reflect.DeepEqual(got, []string{"Edit", "Undo"}) {
+		t.Errorf("expected the chord to activate Edit > Undo, got %v", got)
+	}
+}
+
+func TestClick(t *testing.T) {
+	m := newModel()
+	m, _ = Click(m, 7, 0)
+	if got := m.State().Open; !reflect.DeepEqual(got, []string{"Edit"}) {
+		t.Fatalf("expected the click to open Edit, got %v", got)
+	}
+	_, msgs := Click(m, 8, 2)
+	if got := activated(msgs); !reflect.DeepEqual(got, []string{"Edit", "Undo"}) {
+		t.Errorf("expected Edit > Undo to be activated, got %v", got)
+	}
+}
+
+func TestOpenPath(t *testing.T) {
+	m, err := OpenPath(newModel(), "File", "Recent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.State().Open; !reflect.DeepEqual(got, []string{"File", "Recent"}) {
+		t.Errorf("expected File > Recent to be open, got %v", got)
+	}
+	if _, err := OpenPath(newModel(), "File", "Missing"); err == nil || !strings.Contains(err.Error(), "File > Missing") {
+		t.Errorf("expected an error naming File > Missing, got %v", err)
+	}
+}
+
+func TestViewOrigin(t *testing.T) {
+	styles := menubar.PlainStyles()
+	styles.Bar = styles.Bar.Copy().Border(menubar.ASCIIBorder, false, false, true)
+	m := newModel()
+	m.SetStyles(styles)
+	m.X, m.Y = 3, 2
+	m, _ = OpenPath(m, "File")
+
+	rows := strings.Split(View(m, 20), "\n")
+	if len(rows) < 5 || !strings.HasPrefix(rows[2], "   >File") {
+		t.Fatalf("expected the bar at 3,2:\n%s", strings.Join(rows, "\n"))
+	}
+	if top := m.Y + m.BarHeight(); !strings.HasPrefix(rows[top], "   +") || !strings.Contains(rows[top+1], "Open") {
+		t.Errorf("expected the dropdown below the bar, on row %d:\n%s", top, strings.Join(rows, "\n"))
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	m, _ := OpenPath(newModel(), "File", "Recent")
+	AssertGolden(t, "file_recent", View(m, 30))
+}
@@ -0,0 +1,97 @@
+package menubar
+
+// key identifies an item when trees are merged or edited.
+func (item MenuItem) key() string {
+	if item.ID != "" {
+		return item.ID
+	}
+	return item.Label
+}
+
+// Merge combines two menu trees, like a base menu and the menus of the
+// current mode. Items of b with the same ID, or label when they have none, as
+// an item of a replace it, except that their submenus are merged in turn.
+// Other items of b follow the item of b before them, or go at the end when
+// there is none, so b's order is kept. Neither slice is modified.
+func Merge(a, b []MenuItem) []MenuItem {
+	merged := append([]MenuItem(nil), a...)
+	anchor := -1 // Index in merged of the last item of b placed
+	for _, item := range b {
+		i := -1
+		if !item.IsSeparator {
+			i = indexOfKey(merged, item.key())
+		}
+		switch {
+		case i != -1:
+			if len(merged[i].SubMenu) > 0 && len(item.SubMenu) > 0 {
+				item.SubMenu = Merge(merged[i].SubMenu, item.SubMenu)
+			}
+			merged[i] = item
+		case anchor != -1:
+			i = anchor + 1
+			merged = append(merged[:i], append([]MenuItem{item}, merged[i:]...)...)
+		default:
+			i = len(merged)
+			merged = append(merged, item)
+		}
+		anchor = i
+	}
+
+	// Drop the doubled separators where both trees had one
+	deduped := merged[:0]
+	for i, item := range merged {
+		if item.IsSeparator && i > 0 && merged[i-1].IsSeparator {
+			continue
+		}
+		deduped = append(deduped, item)
+	}
+	return deduped
+}
+
+func indexOfKey(items []MenuItem, key string) int {
+	for i, item := range items {
+		if !item.IsSeparator && item.key() == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// InsertAfterID returns a copy of the tree with item placed right after the
+// item with the given ID, or label when it has none, wherever it is nested.
+// item is appended to the top level when there is no such item.
+func InsertAfterID(items []MenuItem, id string, item MenuItem) []MenuItem {
+	return insertAt(items, id, item, 1)
+}
+
+// InsertBeforeID is like InsertAfterID, placing item right before the item
+// with the given ID.
+func InsertBeforeID(items []MenuItem, id string, item MenuItem) []MenuItem {
+	return insertAt(items, id, item, 0)
+}
+
+func insertAt(items []MenuItem, id string, item MenuItem, offset int) []MenuItem {
+	if inserted, ok := insertNear(items, id, item, offset); ok {
+		return inserted
+	}
+	return append(append([]MenuItem(nil), items...), item)
+}
+
+// insertNear inserts item next to the first item with the key id, copying
+// the slices along the way so the original tree is left alone.
+func insertNear(items []MenuItem, id string, item MenuItem, offset int) ([]MenuItem, bool) {
+	if i := indexOfKey(items, id); i != -1 {
+		i += offset
+		inserted := make([]MenuItem, 0, len(items)+1)
+		inserted = append(append(append(inserted, items[:i]...), item), items[i:]...)
+		return inserted, true
+	}
+	for i := range items {
+		if sub, ok := insertNear(items[i].SubMenu, id, item, offset); ok {
+			copied := append([]MenuItem(nil), items...)
+			copied[i].SubMenu = sub
+			return copied, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,55 @@
+package menubar
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ActivatedMsg is sent whenever an item without a submenu is activated, in
+// addition to whatever the item's Action returns.
+type ActivatedMsg struct {
+	MenuID string   // ID of the menubar the item belongs to
+	Path   []string // Labels from the top-level item down to the activated item
+	Data   any      // Data of the activated item
+}
+
+func (msg ActivatedMsg) menuID() string { return msg.MenuID }
+
+// ActionErrorMsg is sent when an item's ActionErr returns an error. Pass it back
+// to the menubar to highlight the failing menu with Styles.Error until the next
+// key press or click.
+type ActionErrorMsg struct {
+	MenuID string
+	Path   []string
+	Data   any // Data of the failing item
+	Err    error
+}
+
+func (msg ActionErrorMsg) menuID() string { return msg.MenuID }
+
+func (msg ActionErrorMsg) Error() string {
+	return strings.Join(msg.Path, " > ") + ": " + msg.Err.Error()
+}
+
+// OutsideClickMsg is sent when the user clicks outside the bar and its
+// dropdowns while the menubar is focused, whatever its ClickOutside policy.
+type OutsideClickMsg struct {
+	MenuID string
+	X, Y   int
+}
+
+func (msg OutsideClickMsg) menuID() string { return msg.MenuID }
+
+// FocusMsg activates the menubar with a matching ID and deactivates all others.
+type FocusMsg struct {
+	MenuID string
+}
+
+func (msg FocusMsg) menuID() string { return msg.MenuID }
+
+// Focus returns a command that focuses the menubar with the given ID. Pass the
+// message to every menubar so the others release focus.
+func Focus(id string) tea.Cmd {
+	return func() tea.Msg { return FocusMsg{MenuID: id} }
+}
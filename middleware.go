@@ -0,0 +1,23 @@
+package menubar
+
+import tea "github.com/charmbracelet/bubbletea"
+
+type ActionFunc func() tea.Msg
+
+// Middleware wraps every item Action, for cross-cutting concerns like timing,
+// analytics, permission checks or confirmation prompts.
+type Middleware func(next ActionFunc) ActionFunc
+
+// Use adds middleware around every item Action. Middleware added first runs
+// outermost.
+func (m *Model) Use(middleware ...Middleware) {
+	m.middleware = append(m.middleware[:len(m.middleware):len(m.middleware)], middleware...)
+}
+
+func (m Model) wrapAction(action func() tea.Msg) tea.Cmd {
+	next := ActionFunc(action)
+	for i := len(m.middleware) - 1; i >= 0; i-- {
+		next = m.middleware[i](next)
+	}
+	return tea.Cmd(next)
+}
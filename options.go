@@ -0,0 +1,148 @@
+package menubar
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OptionChangedMsg is sent when an item generated by FromOptions changes the
+// underlying settings struct.
+type OptionChangedMsg struct {
+	Field string // Dotted Go field path, e.g. "Editor.TabsToSpaces"
+	Value any
+}
+
+// FromOptions generates menu items from a pointer to a settings struct and
+// keeps the struct updated as items are toggled:
+//
+//   - bool fields become checkboxes
+//   - string fields with an `options:"a,b,c"` tag become a submenu of radio items
+//   - nested structs (or non-nil pointers to them) become submenus
+//
+// Labels are derived from field names, or taken from a `menu:"Label"` tag.
+// Fields tagged `menu:"-"` are skipped. It panics if options is not a pointer
+// to a struct.
+func FromOptions(options any) []MenuItem {
+	v := reflect.ValueOf(options)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		panic("menubar: FromOptions requires a pointer to a struct")
+	}
+	return optionItems(v.Elem(), "")
+}
+
+func optionItems(v reflect.Value, prefix string) []MenuItem {
+	var items []MenuItem
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("menu")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		label := tag
+		if label == "" {
+			label = fieldLabel(sf.Name)
+		}
+		name := prefix + sf.Name
+		field := v.Field(i)
+
+		switch {
+		case field.Kind() == reflect.Bool:
+			items = append(items, MenuItem{
+				Label:     label,
+				Checkable: true,
+				Checked:   field.Bool(),
+				OnToggle: func(checked bool) tea.Cmd {
+					field.SetBool(checked)
+					return optionChanged(name, checked)
+				},
+			})
+		case field.Kind() == reflect.String && sf.Tag.Get("options") != "":
+			var choices []MenuItem
+			for _, option := range strings.Split(sf.Tag.Get("options"), ",") {
+				option := strings.TrimSpace(option)
+				choices = append(choices, MenuItem{
+					Label:      option,
+					RadioGroup: name,
+					Checked:    field.String() == option,
+					OnToggle: func(checked bool) tea.Cmd {
+						if !checked {
+							return nil
+						}
+						field.SetString(option)
+						return optionChanged(name, option)
+					},
+				})
+			}
+			items = append(items, MenuItem{Label: label, SubMenu: choices})
+		case field.Kind() == reflect.Struct:
+			items = append(items, MenuItem{Label: label, SubMenu: optionItems(field, name+".")})
+		case field.Kind() == reflect.Pointer && !field.IsNil() && field.Elem().Kind() == reflect.Struct:
+			items = append(items, MenuItem{Label: label, SubMenu: optionItems(field.Elem(), name+".")})
+		}
+	}
+	return items
+}
+
+func optionChanged(field string, value any) tea.Cmd {
+	return func() tea.Msg { return OptionChangedMsg{Field: field, Value: value} }
+}
+
+// fieldLabel turns a Go field name like "ShowLineNumbers" into "Show Line Numbers".
+func fieldLabel(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Choice is one option of a RadioGroup.
+type Choice[T comparable] struct {
+	Label string
+	Value T
+}
+
+// ChangedMsg is sent when a RadioGroup changes its bound variable.
+type ChangedMsg[T comparable] struct {
+	Label string // Label of the RadioGroup's item
+	Value T
+}
+
+// RadioGroup returns an item whose submenu has one radio item per option,
+// checked while bound holds its value. Choosing an option stores its value in
+// bound and sends a ChangedMsg.
+func RadioGroup[T comparable](label string, options []Choice[T], bound *T) MenuItem {
+	group := fmt.Sprintf("%s %p", label, bound)
+	return MenuItem{Label: label, SubMenuFunc: func() []MenuItem {
+		items := make([]MenuItem, len(options))
+		for i, option := range options {
+			option := option
+			items[i] = MenuItem{
+				Label:      option.Label,
+				RadioGroup: group,
+				Checked:    *bound == option.Value,
+				OnToggle: func(checked bool) tea.Cmd {
+					if !checked {
+						return nil
+					}
+					*bound = option.Value
+					return func() tea.Msg { return ChangedMsg[T]{Label: label, Value: option.Value} }
+				},
+			}
+		}
+		return items
+	}}
+}
@@ -0,0 +1,146 @@
+package menubar
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type editorOptions struct {
+	TabsToSpaces bool
+	TabSize      int // Unsupported kinds are skipped
+}
+
+type options struct {
+	ShowLineNumbers bool
+	Theme           string `options:"Light, Dark"`
+	Font            string // Strings without options are skipped
+	Wrap            bool   `menu:"Word Wrap"`
+	Hidden          bool   `menu:"-"`
+	private         bool
+	Editor          editorOptions
+	Plugins         *editorOptions
+	Missing         *editorOptions // Nil pointers are skipped
+}
+
+// labels returns the labels of items as nested slices, submenus after their
+// parent's label.
+func labels(items []MenuItem) []any {
+	var got []any
+	for _, item := range items {
+		got = append(got, item.Label)
+		if item.SubMenu != nil {
+			got = append(got, labels(item.SubMenu))
+		}
+	}
+	return got
+}
+
+func TestFromOptionsItems(t *testing.T) {
+	opts := options{Theme: "Dark", Plugins: &editorOptions{}}
+	want := []any{
+		"Show Line Numbers",
+		"Theme", []any{"Light", "Dark"},
+		"Word Wrap",
+		"Editor", []any{"Tabs To Spaces"},
+		"Plugins", []any{"Tabs To Spaces"},
+	}
+	if got := labels(FromOptions(&opts)); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestFromOptionsToggle(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  []string
+		field string
+		value any
+		check func(options) bool
+	}{
+		{"bool", []string{"Word Wrap"}, "Wrap", true, func(o options) bool { return o.Wrap }},
+		{"nested struct", []string{"Editor", "Tabs To Spaces"}, "Editor.TabsToSpaces", true, func(o options) bool { return o.Editor.TabsToSpaces }},
+		{"nested pointer", []string{"Plugins", "Tabs To Spaces"}, "Plugins.TabsToSpaces", true, func(o options) bool { return o.Plugins.TabsToSpaces }},
+		{"option", []string{"Theme", "Light"}, "Theme", "Light", func(o options) bool { return o.Theme == "Light" }},
+	}
+	for _, tt := range tests {
+		opts := options{Theme: "Dark", Plugins: &editorOptions{}}
+		m := New(FromOptions(&opts))
+		m.openLabels(tt.path[:len(tt.path)-1], tt.path[len(tt.path)-1])
+		menu := &m
+		for menu.hasOpenSubmenu() {
+			menu = menu.SubMenuState
+		}
+		var changed []tea.Msg
+		for _, msg := range collect(menu.activate(menu.Selection)) {
+			if msg, ok := msg.(OptionChangedMsg); ok {
+				changed = append(changed, msg)
+			}
+		}
+		if !tt.check(opts) {
+			t.Errorf("%s: expected %s to be set, got %+v", tt.name, tt.field, opts)
+		}
+		if want := (OptionChangedMsg{Field: tt.field, Value: tt.value}); len(changed) != 1 || changed[0] != want {
+			t.Errorf("%s: expected %+v, got %v", tt.name, want, changed)
+		}
+	}
+}
+
+func TestFromOptionsRequiresStructPointer(t *testing.T) {
+	for _, v := range []any{options{}, new(int), nil} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected FromOptions(%T) to panic", v)
+				}
+			}()
+			FromOptions(v)
+		}()
+	}
+}
+
+func checkedLabels(items []MenuItem) []string {
+	var checked []string
+	for _, item := range items {
+		if item.Checked {
+			checked = append(checked, item.Label)
+		}
+	}
+	return checked
+}
+
+func TestRadioGroupExclusive(t *testing.T) {
+	size := 2
+	choices := []Choice[int]{{"Small", 1}, {"Medium", 2}, {"Large", 3}}
+	m := New([]MenuItem{{Label: "View", SubMenu: []MenuItem{RadioGroup("Size", choices, &size)}}})
+
+	for _, choice := range []Choice[int]{choices[2], choices[0]} {
+		m.openLabels([]string{"View", "Size"}, choice.Label)
+		sizes := m.SubMenuState.SubMenuState
+		var changed []tea.Msg
+		for _, msg := range collect(sizes.activate(sizes.Selection)) {
+			if msg, ok := msg.(ChangedMsg[int]); ok {
+				changed = append(changed, msg)
+			}
+		}
+		if size != choice.Value {
+			t.Errorf("expected %s to set %d, got %d", choice.Label, choice.Value, size)
+		}
+		if want := (ChangedMsg[int]{Label: "Size", Value: choice.Value}); len(changed) != 1 || changed[0] != want {
+			t.Errorf("expected %+v, got %v", want, changed)
+		}
+
+		if checked := checkedLabels(sizes.Items); !reflect.DeepEqual(checked, []string{choice.Label}) {
+			t.Errorf("expected only %s checked, got %v", choice.Label, checked)
+		}
+
+		// Reopened, the submenu is rebuilt from the bound variable
+		m.closeSubMenu()
+		m.openLabels([]string{"View", "Size"}, "")
+		if checked := checkedLabels(m.SubMenuState.SubMenuState.Items); !reflect.DeepEqual(checked, []string{choice.Label}) {
+			t.Errorf("expected only %s checked once reopened, got %v", choice.Label, checked)
+		}
+		m.closeSubMenu()
+	}
+}
@@ -0,0 +1,164 @@
+package overlay
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+var (
+	sgrRegex       = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	hyperlinkRegex = regexp.MustCompile("\x1b\\]8;[^;\x07\x1b]*;([^\x07\x1b]*)(?:\x07|\x1b\\\\)")
+)
+
+// closeHyperlink ends an OSC 8 hyperlink.
+const closeHyperlink = "\x1b]8;;\x1b\\"
+
+// Width returns the columns s takes up. Unlike lipgloss.Width it skips OSC
+// sequences like hyperlinks, and measures grapheme clusters like emoji
+// sequences as a single character.
+func Width(s string) int {
+	w := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		end := textEnd(s, i)
+		g := uniseg.NewGraphemes(s[i:end])
+		for g.Next() {
+			w += graphemeWidth(g.Str())
+		}
+		i = end
+	}
+	return w
+}
+
+// graphemeWidth returns the columns the grapheme cluster g takes up. Flags,
+// pairs of regional indicators, take two like other emoji, though runewidth
+// counts one for the first indicator only.
+func graphemeWidth(g string) int {
+	if r, n := utf8.DecodeRuneInString(g); r >= 0x1F1E6 && r <= 0x1F1FF && len(g) > n {
+		return 2
+	}
+	return runewidth.StringWidth(g)
+}
+
+// blockWidth returns the width of the widest line.
+func blockWidth(lines []string) int {
+	w := 0
+	for _, line := range lines {
+		if lw := Width(line); lw > w {
+			w = lw
+		}
+	}
+	return w
+}
+
+// split splits s at column width without breaking grapheme clusters or escape
+// sequences. Both halves keep their width, so a wide character straddling the
+// column is replaced by a space on each side.
+func split(s string, width int) (string, string) {
+	end, start, before, after := splitIndex(s, width)
+	return s[:end] + strings.Repeat(" ", before), strings.Repeat(" ", after) + s[start:]
+}
+
+// splitIndex finds column width in s, returning where the part of s before it
+// ends and where the part after it starts. They differ when a character
+// straddles the column, which leaves before columns of it on the left and
+// after columns on the right.
+func splitIndex(s string, width int) (end, start, before, after int) {
+	w := 0
+	for i := 0; i < len(s); {
+		if w == width {
+			return i, i, 0, 0
+		}
+		// Escape sequences take no room and are never split
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		text := textEnd(s, i)
+		g := uniseg.NewGraphemes(s[i:text])
+		for g.Next() {
+			from, to := g.Positions()
+			cw := graphemeWidth(g.Str())
+			if w+cw > width {
+				return i + from, i + to, width - w, w + cw - width
+			}
+			w += cw
+			if w == width {
+				return i + to, i + to, 0, 0
+			}
+		}
+		i = text
+	}
+	return len(s), len(s), 0, 0
+}
+
+// resume returns the styling and hyperlink still open at the end of s, for
+// continuing it elsewhere.
+func resume(s string) string {
+	return strings.Join(sgrRegex.FindAllString(s, -1), "") + openHyperlink(s)
+}
+
+// closing returns what ends the styling and hyperlink still open at the end of
+// s, keeping plain text plain.
+func closing(s string) string {
+	end := ""
+	if strings.Contains(s, "\x1b[") {
+		end = "\x1b[0m"
+	}
+	if openHyperlink(s) != "" {
+		end += closeHyperlink
+	}
+	return end
+}
+
+// openHyperlink returns the sequence opening the OSC 8 hyperlink still open at
+// the end of s, or "" if there is none.
+func openHyperlink(s string) string {
+	links := hyperlinkRegex.FindAllStringSubmatch(s, -1)
+	if len(links) == 0 || links[len(links)-1][1] == "" {
+		return ""
+	}
+	return links[len(links)-1][0]
+}
+
+// textEnd returns the index of the escape sequence after i, or the end of s.
+func textEnd(s string, i int) int {
+	if n := strings.IndexByte(s[i:], '\x1b'); n >= 0 {
+		return i + n
+	}
+	return len(s)
+}
+
+// escapeLen returns the length of the escape sequence s starts with, or 0.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+	switch s[1] {
+	case '[': // CSI, ended by a byte in @ to ~
+		for i := 2; i < len(s); i++ {
+			if s[i] >= '@' && s[i] <= '~' {
+				return i + 1
+			}
+		}
+	case ']': // OSC, ended by BEL or ST
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return 2
+	}
+	return len(s)
+}
@@ -0,0 +1,89 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/rivo/uniseg"
+)
+
+const (
+	combining = "e\u0301"                    // e and a combining acute accent, one column
+	zwj       = "\U0001F469\u200D\U0001F4BB" // Woman technologist, a ZWJ sequence two columns wide
+	flag      = "\U0001F1EF\U0001F1F5"       // Japan, a pair of regional indicators two columns wide
+)
+
+func TestWidthClusters(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{combining, 1},
+		{zwj, 2},
+		{flag, 2},
+		{"a" + combining + zwj + flag + "b", 7},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.s); got != tt.want {
+			t.Errorf("Width(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestPlaceClusters(t *testing.T) {
+	tests := []struct {
+		name   string
+		bg, fg string
+		x      int
+		want   string
+	}{
+		{"over a combining mark", "a" + combining + "b", "X", 1, "aXb"},
+		{"after a combining mark", "a" + combining + "b", "X", 2, "a" + combining + "X"},
+		{"over the left of a ZWJ sequence", "a" + zwj + "b", "X", 1, "aX b"},
+		{"over the right of a ZWJ sequence", "a" + zwj + "b", "X", 2, "a Xb"},
+		{"over a whole ZWJ sequence", "a" + zwj + "b", "XY", 1, "aXYb"},
+		{"over the left of a flag", flag + flag, "X", 2, flag + "X "},
+		{"over the right of a flag", flag + flag, "X", 1, " X" + flag},
+		{"between flags", flag + flag, "XY", 1, " XY "},
+		{"negative x through a ZWJ sequence", "abcd", zwj + "Z", -1, " Zcd"},
+		{"negative x after a combining mark", "abcd", "Y" + combining + "Z", -1, combining + "Zcd"},
+	}
+	for _, tt := range tests {
+		got := Place(tt.bg, tt.fg, tt.x, 0)
+		if got != tt.want {
+			t.Errorf("%s: Place(%q, %q, %d, 0) = %q, want %q", tt.name, tt.bg, tt.fg, tt.x, got, tt.want)
+		}
+	}
+}
+
+// TestPlaceKeepsClusters places a single character at every column of lines
+// mixing clusters, checking that each cluster is either kept whole or replaced
+// by spaces, and that every column keeps its width.
+func TestPlaceKeepsClusters(t *testing.T) {
+	for _, bg := range []string{
+		"a" + combining + zwj + flag + "b",
+		zwj + zwj + zwj,
+		flag + combining + flag,
+		"\u4e16" + zwj + "\u754c" + combining,
+	} {
+		allowed := map[string]bool{"X": true, " ": true}
+		g := uniseg.NewGraphemes(bg)
+		for g.Next() {
+			allowed[g.Str()] = true
+		}
+		for x := 0; x < Width(bg); x++ {
+			got := Place(bg, "X", x, 0)
+			if Width(got) != Width(bg) {
+				t.Errorf("Place(%q, X, %d) = %q, %d columns wide, want %d", bg, x, got, Width(got), Width(bg))
+			}
+			g := uniseg.NewGraphemes(got)
+			for col := 0; g.Next(); col += Width(g.Str()) {
+				if !allowed[g.Str()] {
+					t.Errorf("Place(%q, X, %d) = %q, cutting a cluster into %q", bg, x, got, g.Str())
+				}
+				if col == x && g.Str() != "X" {
+					t.Errorf("Place(%q, X, %d) = %q, with %q at the column", bg, x, got, g.Str())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package overlay draws one block of text over another, for composing
+// dropdowns, dialogs and other popups over an application's view. It keeps
+// the ANSI styling and OSC 8 hyperlinks of both, and never splits wide
+// characters or grapheme clusters.
+package overlay
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+)
+
+type options struct {
+	h, v        lipgloss.Position
+	transparent map[rune]bool
+	clip        bool
+}
+
+// Option configures Place.
+type Option func(*options)
+
+// Anchor places fg relative to a point of bg instead of its top left corner,
+// like lipgloss.Place: lipgloss.Center, lipgloss.Center centers it, and
+// lipgloss.Right, lipgloss.Bottom puts it in the bottom right corner. The x
+// and y given to Place are offsets from there.
+func Anchor(h, v lipgloss.Position) Option {
+	return func(o *options) {
+		o.h, o.v = h, v
+	}
+}
+
+// Transparent shows bg through the cells of fg holding one of runes, like the
+// spaces around a shape.
+func Transparent(runes ...rune) Option {
+	return func(o *options) {
+		if o.transparent == nil {
+			o.transparent = make(map[rune]bool)
+		}
+		for _, r := range runes {
+			o.transparent[r] = true
+		}
+	}
+}
+
+// Clip cuts fg off at the right and bottom edges of bg instead of growing bg
+// to fit it.
+func Clip() Option {
+	return func(o *options) {
+		o.clip = true
+	}
+}
+
+// Place draws fg over bg with its top left corner at column x of row y. The
+// styling and hyperlinks of bg end at fg and resume after it, and a wide
+// character of bg that fg covers only partly is replaced by spaces. The parts
+// of fg left of or above bg are cut off.
+func Place(bg, fg string, x, y int, opts ...Option) string {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	bgLines := strings.Split(bg, "\n")
+	fgLines := strings.Split(fg, "\n")
+	width := blockWidth(bgLines)
+	if o.h != 0 || o.v != 0 {
+		x += int(math.Round(float64(width-blockWidth(fgLines)) * float64(o.h)))
+		y += int(math.Round(float64(len(bgLines)-len(fgLines)) * float64(o.v)))
+	}
+
+	for i, fgLine := range fgLines {
+		row, col := y+i, x
+		if row < 0 {
+			continue
+		}
+		if o.clip && row >= len(bgLines) {
+			break
+		}
+		if col < 0 {
+			_, start, _, after := splitIndex(fgLine, -col)
+			fgLine = resume(fgLine[:start]) + strings.Repeat(" ", after) + fgLine[start:]
+			col = 0
+		}
+		if o.clip {
+			if col >= width {
+				continue
+			}
+			fgLine, _ = split(fgLine, width-col)
+			fgLine += closing(fgLine)
+		}
+		for row >= len(bgLines) {
+			bgLines = append(bgLines, "")
+		}
+		for _, seg := range segments(fgLine, o.transparent) {
+			bgLines[row] = splice(bgLines[row], seg.text, col+seg.col)
+		}
+	}
+	return strings.Join(bgLines, "\n")
+}
+
+// splice draws the single line fg over bg at column x.
+func splice(bg, fg string, x int) string {
+	bgWidth := Width(bg)
+	if bgWidth < x {
+		return bg + closing(bg) + strings.Repeat(" ", x-bgWidth) + fg
+	}
+	prefix, _ := split(bg, x)
+	preSuffix, suffix := split(bg, x+Width(fg))
+	return prefix + closing(prefix) + fg + resume(preSuffix) + suffix
+}
+
+// segment is a run of opaque cells of a line, starting at column col.
+type segment struct {
+	col  int
+	text string
+}
+
+// segments splits line into the runs of cells that aren't transparent, each
+// carrying the styling and hyperlink it had in line.
+func segments(line string, transparent map[rune]bool) []segment {
+	if len(transparent) == 0 {
+		return []segment{{text: line}}
+	}
+	var segs []segment
+	start, startCol, col := -1, 0, 0
+	flush := func(end int) {
+		if start >= 0 {
+			segs = append(segs, segment{col: startCol, text: resume(line[:start]) + line[start:end] + closing(line[:end])})
+			start = -1
+		}
+	}
+	for i := 0; i < len(line); {
+		if n := escapeLen(line[i:]); n > 0 {
+			i += n
+			continue
+		}
+		end := textEnd(line, i)
+		g := uniseg.NewGraphemes(line[i:end])
+		for g.Next() {
+			from, _ := g.Positions()
+			if r := g.Runes(); len(r) == 1 && transparent[r[0]] {
+				flush(i + from)
+			} else if start < 0 {
+				start, startCol = i+from, col
+			}
+			col += graphemeWidth(g.Str())
+		}
+		i = end
+	}
+	flush(len(line))
+	return segs
+}
@@ -0,0 +1,97 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const link = "\x1b]8;;http://x\x1b\\"
+
+func TestPlace(t *testing.T) {
+	tests := []struct {
+		name   string
+		bg, fg string
+		x, y   int
+		opts   []Option
+		want   string
+	}{
+		{"plain", "abcdef", "XY", 2, 0, nil, "abXYef"},
+		{"past the end", "ab", "X", 4, 0, nil, "ab  X"},
+		{"below", "ab", "X", 1, 2, nil, "ab\n\n X"},
+		{"wide char straddling the left edge", "世界ab", "X", 1, 0, nil, " X界ab"},
+		{"wide char straddling the right edge", "a世b", "X", 2, 0, nil, "a Xb"},
+		{"wide char covered", "a世b", "XY", 1, 0, nil, "aXYb"},
+
+		{"negative x", "abcdef", "XYZ", -1, 0, nil, "YZcdef"},
+		{"negative x past fg", "abcdef", "XYZ", -4, 0, nil, "abcdef"},
+		{"negative x straddling a wide char", "abcdef", "世界", -1, 0, nil, " 界def"},
+		{"negative x keeps styling", "abcdef", "\x1b[31m世界\x1b[0m", -1, 0, nil, "\x1b[31m 界\x1b[0mdef"},
+		{"negative y", "abc\ndef", "X\nY", 1, -1, nil, "aYc\ndef"},
+
+		{"clip", "abc\ndef", "XYZW\nQ", 1, 1, []Option{Clip()}, "abc\ndXY"},
+		{"no clip", "abc\ndef", "XYZW\nQ", 1, 1, nil, "abc\ndXYZW\n Q"},
+		{"clip right of bg", "abc", "X", 3, 0, []Option{Clip()}, "abc"},
+		{"clip a wide char", "abcd", "X世", 2, 0, []Option{Clip()}, "abX "},
+		{"clip closes styling", "abcd", "\x1b[1mXYZ", 2, 0, []Option{Clip()}, "ab\x1b[1mXY\x1b[0m"},
+
+		{"transparent", "abcdef", "X  Y", 1, 0, []Option{Transparent(' ')}, "aXcdYf"},
+		{"transparent keeps styling", "abcdef", "\x1b[1mX Y\x1b[0m", 1, 0, []Option{Transparent(' ')}, "a\x1b[1mX\x1b[0mc\x1b[0m\x1b[1mY\x1b[0m\x1b[0m\x1b[1m\x1b[0mef"},
+
+		{"anchor center", "aaaaa\nbbbbb\nccccc", "X", 0, 0, []Option{Anchor(lipgloss.Center, lipgloss.Center)}, "aaaaa\nbbXbb\nccccc"},
+		{"anchor bottom right with offset", "aaaaa\nbbbbb\nccccc", "X", -1, 0, []Option{Anchor(lipgloss.Right, lipgloss.Bottom)}, "aaaaa\nbbbbb\ncccXc"},
+
+		{"SGR resumes after fg", "\x1b[31mabcdef\x1b[0m", "XY", 2, 0, nil, "\x1b[31mab\x1b[0mXY\x1b[31mef\x1b[0m"},
+		{"hyperlink closes and resumes", link + "abcdef\x1b]8;;\x1b\\", "XY", 2, 0, nil,
+			link + "ab\x1b]8;;\x1b\\XY" + link + "ef\x1b]8;;\x1b\\"},
+		{"closed hyperlink isn't resumed", link + "ab\x1b]8;;\x1b\\cdef", "XY", 3, 0, nil,
+			link + "ab\x1b]8;;\x1b\\cXYf"},
+	}
+	for _, tt := range tests {
+		if got := Place(tt.bg, tt.fg, tt.x, tt.y, tt.opts...); got != tt.want {
+			t.Errorf("%s: Place(%q, %q, %d, %d) = %q, want %q", tt.name, tt.bg, tt.fg, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"abc", 3},
+		{"世界", 4},
+		{"\x1b[31mab\x1b[0m", 2},
+		{link + "ab\x1b]8;;\x1b\\", 2},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.s); got != tt.want {
+			t.Errorf("Width(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		s                         string
+		width                     int
+		end, start, before, after int
+		left, right               string
+	}{
+		{"abcd", 2, 2, 2, 0, 0, "ab", "cd"},
+		{"ab", 4, 2, 2, 0, 0, "ab", ""},
+		{"世界", 1, 0, 3, 1, 1, " ", " 界"},
+		{"世界", 2, 3, 3, 0, 0, "世", "界"},
+		{"\x1b[31m世界", 3, 8, 11, 1, 1, "\x1b[31m世 ", " "},
+	}
+	for _, tt := range tests {
+		end, start, before, after := splitIndex(tt.s, tt.width)
+		if end != tt.end || start != tt.start || before != tt.before || after != tt.after {
+			t.Errorf("splitIndex(%q, %d) = %d, %d, %d, %d, want %d, %d, %d, %d",
+				tt.s, tt.width, end, start, before, after, tt.end, tt.start, tt.before, tt.after)
+		}
+		if left, right := split(tt.s, tt.width); left != tt.left || right != tt.right {
+			t.Errorf("split(%q, %d) = %q, %q, want %q, %q", tt.s, tt.width, left, right, tt.left, tt.right)
+		}
+	}
+}
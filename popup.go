@@ -0,0 +1,77 @@
+package menubar
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// closePopupMsg closes the open popup, see ClosePopup.
+type closePopupMsg struct {
+	MenuID string
+	result tea.Msg
+}
+
+func (msg closePopupMsg) menuID() string { return msg.MenuID }
+
+// ClosePopup returns a command that closes the popup opened by an item's
+// Popup in the menubar with the given ID, and then sends result if it isn't
+// nil. Popups return it once they are done, e.g. when a color is picked.
+func ClosePopup(id string, result tea.Msg) tea.Cmd {
+	return func() tea.Msg { return closePopupMsg{MenuID: id, result: result} }
+}
+
+// openPopup opens the popup of the selected item as its dropdown. Its Init
+// command is held until the update finishes, see startPopups.
+func (m *Model) openPopup(item MenuItem) {
+	sub := m.newSubMenu(item, nil)
+	sub.popup = item.Popup()
+	sub.starts = sub.popup.Init()
+	m.OpenSubMenu = m.Selection
+	m.SubMenuState = &sub
+}
+
+// startPopups returns the Init commands of popups opened since the last
+// update.
+func (m Model) startPopups() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, menu := range []*Model{m.SubMenuState, m.burger} {
+		for ; menu != nil; menu = menu.SubMenuState {
+			if menu.starts != nil {
+				cmds = append(cmds, menu.starts)
+				menu.starts = nil
+			}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// updatePopup passes messages to the popup, closing it on esc or once it
+// returns ClosePopup.
+func (m Model) updatePopup(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case closePopupMsg:
+		if msg.MenuID != m.ID {
+			break
+		}
+		m.Active = false
+		if msg.result == nil {
+			return m, nil
+		}
+		return m, func() tea.Msg { return msg.result }
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyEsc {
+			m.Active = false
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.popup, cmd = m.popup.Update(msg)
+	return m, cmd
+}
+
+// popupMouse passes a mouse event over the dropdown at baseX, baseY to the
+// popup, relative to the top left corner of its view.
+func (m *Model) popupMouse(msg tea.MouseMsg, baseX, baseY int) tea.Cmd {
+	msg.X -= baseX + m.Styles.Dropdown.GetBorderLeftSize() + m.Styles.Dropdown.GetPaddingLeft()
+	msg.Y -= baseY + m.Styles.Dropdown.GetBorderTopSize() + m.Styles.Dropdown.GetPaddingTop()
+	var cmd tea.Cmd
+	m.popup, cmd = m.popup.Update(msg)
+	return cmd
+}
@@ -0,0 +1,34 @@
+package menubar
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestClosePopupRoutesByID(t *testing.T) {
+	type pickedMsg struct{}
+	bars := make([]Model, 2)
+	for i, id := range []string{"a", "b"} {
+		bars[i] = New([]MenuItem{{Label: "Color", Popup: func() tea.Model { return stubModel{} }}})
+		bars[i].ID = id
+		bars[i].Open(0)
+		if !bars[i].hasOpenSubmenu() || bars[i].SubMenuState.popup == nil {
+			t.Fatalf("expected the popup of %s to open", id)
+		}
+	}
+
+	msg := ClosePopup("a", pickedMsg{})()
+	var cmd tea.Cmd
+	bars[0], cmd = bars[0].Update(msg)
+	if bars[0].hasOpenSubmenu() {
+		t.Error("expected the popup of a to close")
+	}
+	if msgs := collect(cmd); len(msgs) != 1 || msgs[0] != (pickedMsg{}) {
+		t.Errorf("expected the result to be sent, got %v", msgs)
+	}
+	bars[1], _ = bars[1].Update(msg)
+	if !bars[1].hasOpenSubmenu() {
+		t.Error("expected the popup of b to stay open")
+	}
+}
@@ -0,0 +1,30 @@
+package menubar
+
+import "github.com/charmbracelet/lipgloss"
+
+// previewLayer returns the Preview of the selected item in the innermost open
+// dropdown, left of the cascade of dropdowns, opposite the side submenus open
+// on, or right of the innermost dropdown when there is no room left of x, the
+// screen column of the first one. Positions are relative to the first
+// dropdown, like getLayersRecursive's.
+func (m Model) previewLayer(x int) (DropdownLayer, bool) {
+	if !m.hasOpenSubmenu() {
+		return DropdownLayer{}, false
+	}
+	menu, left, top := m.SubMenuState, 0, 0
+	for menu.hasOpenSubmenu() {
+		left, top = menu.subMenuOrigin(left, top)
+		menu = menu.SubMenuState
+	}
+	if menu.Selection < 0 || menu.Selection >= len(menu.Items) || menu.Items[menu.Selection].Preview == nil {
+		return DropdownLayer{}, false
+	}
+
+	view := m.Styles.Preview.Render(menu.Items[menu.Selection].Preview())
+	width, _ := menu.getDropdownDimensions()
+	layer := DropdownLayer{Content: view, X: -lipgloss.Width(view), Y: top}
+	if x+layer.X < 0 {
+		layer.X = left + width
+	}
+	return layer, true
+}
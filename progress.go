@@ -0,0 +1,36 @@
+package menubar
+
+import "github.com/charmbracelet/bubbles/progress"
+
+// progressWidth is the width of the progress segment, percentage included.
+const progressWidth = 16
+
+// SetProgress shows a progress bar on the right side of the bar, for
+// background work started from menu actions. percent ranges from 0 to 1.
+func (m *Model) SetProgress(percent float64) {
+	m.touch()
+	if percent < 0 {
+		percent = 0
+	} else if percent > 1 {
+		percent = 1
+	}
+	m.progress, m.showProgress = percent, true
+}
+
+// ClearProgress hides the progress bar shown with SetProgress.
+func (m *Model) ClearProgress() {
+	m.touch()
+	m.progress, m.showProgress = 0, false
+}
+
+func (m Model) progressView() string {
+	if !m.showProgress {
+		return ""
+	}
+	bar := progress.New(
+		progress.WithDefaultGradient(),
+		progress.WithWidth(progressWidth),
+		progress.WithColorProfile(m.colorProfile()),
+	)
+	return bar.ViewAs(m.progress)
+}
@@ -0,0 +1,75 @@
+package menubar
+
+import (
+	"sort"
+	"sync"
+)
+
+// Registry collects items contributed to named top-level menus by independent
+// parts of an application, like plugins, and assembles them into a menu tree.
+// The zero value is ready to use.
+type Registry struct {
+	mu            sync.Mutex
+	menus         []string // Top-level menus, in order of first contribution
+	contributions map[string][]contribution
+}
+
+type contribution struct {
+	item        MenuItem
+	weight      int
+	contributor string
+}
+
+// ContributionOption configures an item passed to Registry.Contribute.
+type ContributionOption func(*contribution)
+
+// Weight orders an item within its menu, lighter items first. Items of equal
+// weight keep the order they were contributed in.
+func Weight(weight int) ContributionOption {
+	return func(c *contribution) { c.weight = weight }
+}
+
+// From names the part of the application contributing an item. Separators
+// are placed between the items of different contributors.
+func From(contributor string) ContributionOption {
+	return func(c *contribution) { c.contributor = contributor }
+}
+
+// Contribute adds item to the top-level menu with the given label.
+func (r *Registry) Contribute(menu string, item MenuItem, opts ...ContributionOption) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.contributions == nil {
+		r.contributions = make(map[string][]contribution)
+	}
+	if _, ok := r.contributions[menu]; !ok {
+		r.menus = append(r.menus, menu)
+	}
+	c := contribution{item: item}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	r.contributions[menu] = append(r.contributions[menu], c)
+}
+
+// Items assembles the top-level menus, each with its items in weight order.
+func (r *Registry) Items() []MenuItem {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := make([]MenuItem, len(r.menus))
+	for i, menu := range r.menus {
+		contributions := append([]contribution(nil), r.contributions[menu]...)
+		sort.SliceStable(contributions, func(i, j int) bool {
+			return contributions[i].weight < contributions[j].weight
+		})
+		var sub []MenuItem
+		for j, c := range contributions {
+			if j > 0 && c.contributor != contributions[j-1].contributor {
+				sub = append(sub, Separator())
+			}
+			sub = append(sub, c.item)
+		}
+		items[i] = MenuItem{Label: menu, SubMenu: sub}
+	}
+	return items
+}
@@ -0,0 +1,57 @@
+package menubar
+
+import (
+	"io"
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// SetRenderer renders the menubar with r instead of lipgloss's default
+// renderer, rebinding its styles to r, so colors match the terminal of each
+// session when serving the program over SSH with Wish:
+//
+//	m.SetRenderer(bubbletea.MakeRenderer(session))
+//
+// Styles set later with SetStyles are bound to r too.
+func (m *Model) SetRenderer(r *lipgloss.Renderer) {
+	m.touch()
+	m.renderer = r
+	m.SetStyles(m.Styles)
+}
+
+// bindStyles returns styles rendered by r.
+func bindStyles(styles Styles, r *lipgloss.Renderer) Styles {
+	v := reflect.ValueOf(&styles).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if style, ok := v.Field(i).Interface().(lipgloss.Style); ok {
+			v.Field(i).Set(reflect.ValueOf(style.Renderer(r)))
+		}
+	}
+	return styles
+}
+
+// newStyle returns a style rendered like the menubar's own.
+func (m Model) newStyle() lipgloss.Style {
+	if m.renderer != nil {
+		return m.renderer.NewStyle()
+	}
+	return lipgloss.NewStyle()
+}
+
+// output returns where the program writes, the output of the renderer set
+// with SetRenderer, such as an SSH session, or else of the default renderer.
+func (m Model) output() io.Writer {
+	if m.renderer != nil {
+		return m.renderer.Output()
+	}
+	return lipgloss.DefaultRenderer().Output()
+}
+
+func (m Model) colorProfile() termenv.Profile {
+	if m.renderer != nil {
+		return m.renderer.ColorProfile()
+	}
+	return lipgloss.ColorProfile()
+}
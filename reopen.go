@@ -0,0 +1,34 @@
+package menubar
+
+// ReopenLast reopens the menus that were open when the bar last closed its
+// menus, with the same item selected, focusing the bar. It reports false when
+// no menus have been closed yet.
+func (m *Model) ReopenLast() bool {
+	m.touch()
+	if m.lastOpen == nil {
+		return false
+	}
+	open, selected := m.lastOpen.Open, m.lastOpen.Selected
+	m.Active = true
+	m.closeSubMenu()
+	m.openLabels(open, selected)
+	return true
+}
+
+// rememberOpen records the open menus for ReopenLast before the bar closes
+// them.
+func (m *Model) rememberOpen() {
+	if m.isDropdown || !m.hasOpenSubmenu() {
+		return
+	}
+	last := &ModelState{}
+	menu := m
+	for menu.hasOpenSubmenu() {
+		last.Open = append(last.Open, menu.Items[menu.OpenSubMenu].Label)
+		menu = menu.SubMenuState
+	}
+	if menu.Selection >= 0 && menu.Selection < len(menu.Items) {
+		last.Selected = menu.Items[menu.Selection].Label
+	}
+	m.lastOpen = last
+}
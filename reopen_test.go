@@ -0,0 +1,21 @@
+package menubar
+
+import "testing"
+
+func TestReopenLast(t *testing.T) {
+	m := New([]MenuItem{
+		{Label: "File", SubMenu: []MenuItem{{Label: "New"}}},
+		{Label: "Edit", SubMenu: []MenuItem{{Label: "Find", SubMenu: []MenuItem{{Label: "Next"}, {Label: "Advanced"}}}}},
+	})
+	m.Open(1)
+	m.SubMenuState.Open(0)
+	m.SubMenuState.SubMenuState.SetSelection(1)
+	m.Deactivate()
+
+	if !m.ReopenLast() {
+		t.Fatal("expected ReopenLast to reopen the menus")
+	}
+	if item, ok := m.SelectedItem(); !ok || item.Label != "Advanced" {
+		t.Errorf("selected %q, want Advanced", item.Label)
+	}
+}
@@ -0,0 +1,64 @@
+package menubar
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Revision returns a number that increases whenever Update or one of the
+// menubar's methods changes what it shows. Hosts can compare it between
+// frames to skip recomposing unchanged views. Setting fields directly, like
+// Items, isn't counted.
+func (m Model) Revision() uint64 {
+	return m.revision
+}
+
+// Changed reports whether what the menubar shows may have changed since it
+// was at revision rev.
+func (m Model) Changed(rev uint64) bool {
+	return m.revision != rev
+}
+
+// touch counts a change to what the menubar shows, see Revision.
+func (m *Model) touch() {
+	m.revision++
+}
+
+// mayChange reports whether Update could change what the menubar shows when
+// given msg. It errs on the side of yes.
+func (m Model) mayChange(msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return !m.DisableKeyboard
+	case tea.MouseMsg:
+		// Motion only highlights items while the menus are in use
+		return !m.DisableMouse && (msg.Type != tea.MouseMotion || m.Active || m.dialog != nil)
+	case tea.WindowSizeMsg, FocusMsg, openDialogMsg, toastMsg:
+		return true
+	case spinner.TickMsg:
+		return m.busy
+	}
+	if _, ok := terminalFocus(msg); ok {
+		return true
+	}
+	if msg, ok := msg.(interface{ menuID() string }); ok {
+		return msg.menuID() == m.ID
+	}
+	// Anything else only reaches models that take any message, or ends
+	// something temporary like a toast, flash or chord
+	return m.dialog != nil || len(m.Widgets) > 0 || m.busy || len(m.toasts) > 0 ||
+		m.flashing || m.message != "" || len(m.chord) > 0 || m.coalescing || m.embedsModel()
+}
+
+// embedsModel reports whether an open dropdown shows a model, like a text
+// input or list, that may update on any message.
+func (m Model) embedsModel() bool {
+	for _, menu := range []*Model{&m, m.burger} {
+		for ; menu != nil; menu = menu.SubMenuState {
+			if menu.input != nil || menu.list != nil || menu.picker != nil || menu.popup != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
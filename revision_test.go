@@ -0,0 +1,40 @@
+package menubar
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRevision(t *testing.T) {
+	type hostMsg struct{}
+	m := New([]MenuItem{{Label: "File", SubMenu: []MenuItem{{Label: "New"}}}})
+	m.ID = "main"
+	m.Deactivate()
+
+	unchanged := []tea.Msg{
+		hostMsg{},
+		tea.MouseMsg{Type: tea.MouseMotion, X: 40, Y: 10},
+		ActivatedMsg{MenuID: "other"},
+	}
+	for _, msg := range unchanged {
+		rev := m.Revision()
+		m, _ = m.Update(msg)
+		if m.Changed(rev) {
+			t.Errorf("%T changed the revision", msg)
+		}
+	}
+
+	changed := []func(){
+		func() { m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown}) },
+		func() { m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24}) },
+		func() { m.Open(0) },
+	}
+	for i, change := range changed {
+		rev := m.Revision()
+		change()
+		if !m.Changed(rev) {
+			t.Errorf("change %d didn't change the revision", i)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package menubar
+
+import "github.com/charmbracelet/lipgloss"
+
+// Rows of layoutRows that aren't items.
+const (
+	scrollUpRow   = -1
+	scrollDownRow = -2
+)
+
+// pinned returns how many items at the start and at the end of the dropdown
+// are Pinned, staying in place while the items between them scroll.
+func (m Model) pinned() (int, int) {
+	if !m.isDropdown {
+		return 0, 0
+	}
+	head := 0
+	for head < len(m.Items) && m.Items[head].Pinned {
+		head++
+	}
+	tail := 0
+	for tail < len(m.Items)-head && m.Items[len(m.Items)-1-tail].Pinned {
+		tail++
+	}
+	return head, tail
+}
+
+// visibleRange returns the half-open range of the scrolling item indexes a
+// dropdown renders, between its pinned items. Only this window is rendered and
+// hit tested, so huge menus stay cheap.
+func (m Model) visibleRange() (int, int) {
+	head, tail := m.pinned()
+	rows, end := m.visibleRows(), len(m.Items)-tail
+	if !m.isDropdown || rows <= 0 || end-head <= rows {
+		return head, end
+	}
+	start := m.scroll
+	if start > end-rows {
+		start = end - rows
+	}
+	if start < head {
+		start = head
+	}
+	return start, start + rows
+}
+
+// visibleItems returns the indexes of the items a dropdown renders, in order.
+func (m Model) visibleItems() []int {
+	head, tail := m.pinned()
+	start, end := m.visibleRange()
+	items := make([]int, 0, head+end-start+tail)
+	for i := 0; i < head; i++ {
+		items = append(items, i)
+	}
+	for i := start; i < end; i++ {
+		items = append(items, i)
+	}
+	for i := len(m.Items) - tail; i < len(m.Items); i++ {
+		items = append(items, i)
+	}
+	return items
+}
+
+// layoutRows returns the rows of the dropdown inside its frame, as item
+// indexes or scrollUpRow and scrollDownRow, along with their heights.
+func (m Model) layoutRows() ([]int, []int) {
+	items, heights := m.visibleItems(), m.rowHeights()
+	if !m.scrollable() {
+		return items, heights
+	}
+	head, _ := m.pinned()
+	start, end := m.visibleRange()
+	n := head + end - start
+	rows := append(append(append(append(make([]int, 0, len(items)+2), items[:head]...), scrollUpRow), items[head:n]...), scrollDownRow)
+	rowHeights := append(append(append(append(make([]int, 0, len(items)+2), heights[:head]...), 1), heights[head:n]...), 1)
+	return append(rows, items[n:]...), append(rowHeights, heights[n:]...)
+}
+
+// rowOffset returns the rows above item i inside the dropdown's frame.
+func (m Model) rowOffset(i int) int {
+	rows, heights := m.layoutRows()
+	y := 0
+	for j, row := range rows {
+		if row == i {
+			break
+		}
+		y += heights[j]
+	}
+	return y
+}
+
+// visibleRows returns the rows shown before scrolling, set by the Height of
+// the item that opened this dropdown or else MaxVisibleItems. Pinned items
+// don't count.
+func (m Model) visibleRows() int {
+	if m.height > 0 {
+		return m.height
+	}
+	return m.MaxVisibleItems
+}
+
+// scrollToSelection scrolls just enough to bring the selection into view.
+func (m *Model) scrollToSelection() {
+	rows := m.visibleRows()
+	head, tail := m.pinned()
+	if rows <= 0 || m.Selection < head || m.Selection >= len(m.Items)-tail {
+		return
+	}
+	if m.Selection < m.scroll {
+		m.scroll = m.Selection
+	} else if m.Selection >= m.scroll+rows {
+		m.scroll = m.Selection - rows + 1
+	}
+	m.scroll, _ = m.visibleRange()
+}
+
+func (m *Model) scrollBy(n int) {
+	m.scroll += n
+	m.scroll, _ = m.visibleRange()
+}
+
+// scrollable reports whether the dropdown shows fewer items than it has, with
+// a scroll indicator row above and below the ones that scroll.
+func (m Model) scrollable() bool {
+	head, tail := m.pinned()
+	rows := m.visibleRows()
+	return m.isDropdown && rows > 0 && len(m.Items)-head-tail > rows
+}
+
+// scrollIndicator renders the indicator row above (dir -1) or below (dir 1)
+// the scrolling items, blank when there is nothing more that way.
+func (m Model) scrollIndicator(width, dir int) string {
+	head, tail := m.pinned()
+	start, end := m.visibleRange()
+	glyph := ""
+	if dir < 0 && start > head {
+		glyph = m.Glyphs.ScrollUp
+	} else if dir > 0 && end < len(m.Items)-tail {
+		glyph = m.Glyphs.ScrollDown
+	}
+	return m.Styles.ScrollIndicator.Copy().Width(width).Align(lipgloss.Center).Render(glyph)
+}
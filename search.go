@@ -0,0 +1,185 @@
+package menubar
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxSearchResults is how many matches a search lists.
+const maxSearchResults = 10
+
+// search is a search of every menu's labels, started with the SearchKey.
+type search struct {
+	query   string
+	results []searchResult
+	index   int // Selected result
+}
+
+type searchResult struct {
+	path       []int  // Indexes from the top-level item down to the match
+	label      string // Labels along path, as shown
+	start, end int    // Bytes of label matching the query
+}
+
+// startSearch starts a search when msg is the SearchKey and the bar is focused.
+func (m *Model) startSearch(msg tea.KeyMsg) bool {
+	if !m.Active || m.SearchKey == "" || keyName(msg) != canonicalKey(m.SearchKey) || m.editingInput() || m.collapsed() {
+		return false
+	}
+	m.closeSubMenu()
+	m.search = &search{}
+	return true
+}
+
+// updateSearch edits the query and picks a result, opening the menus down to
+// it on enter.
+func (m Model) updateSearch(msg tea.KeyMsg) (Model, tea.Cmd) {
+	s := *m.search
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.search = nil
+		return m, nil
+	case tea.KeyEnter:
+		m.search = nil
+		if s.index < len(s.results) {
+			m.openSearchResult(s.results[s.index].path)
+		}
+		return m, nil
+	case tea.KeyUp, tea.KeyShiftTab:
+		if s.index > 0 {
+			s.index--
+		}
+	case tea.KeyDown, tea.KeyTab:
+		if s.index < len(s.results)-1 {
+			s.index++
+		}
+	case tea.KeyBackspace:
+		if r := []rune(s.query); len(r) > 0 {
+			s.query = string(r[:len(r)-1])
+			s.results, s.index = m.searchItems(s.query), 0
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		s.query += string(msg.Runes)
+		s.results, s.index = m.searchItems(s.query), 0
+	}
+	m.search = &s
+	return m, nil
+}
+
+// searchItems returns the items whose labels contain query, ignoring case,
+// looking through every SubMenu. Submenus built by SubMenuFunc aren't searched.
+func (m Model) searchItems(query string) []searchResult {
+	if query == "" {
+		return nil
+	}
+	var results []searchResult
+	var walk func(items []MenuItem, path []int, labels []string)
+	walk = func(items []MenuItem, path []int, labels []string) {
+		for i, item := range items {
+			if len(results) == maxSearchResults {
+				return
+			}
+			if item.IsSeparator || item.Disabled {
+				continue
+			}
+			itemPath := append(append([]int(nil), path...), i)
+			itemLabels := append(append([]string(nil), labels...), stripANSI(m.tr(item.label())))
+			if start, end := indexFold(itemLabels[len(itemLabels)-1], query); start >= 0 {
+				label := strings.Join(itemLabels, " > ")
+				offset := len(label) - len(itemLabels[len(itemLabels)-1])
+				results = append(results, searchResult{path: itemPath, label: label, start: offset + start, end: offset + end})
+			}
+			walk(item.SubMenu, itemPath, itemLabels)
+		}
+	}
+	walk(m.Items, nil, nil)
+	return results
+}
+
+// indexFold returns the bytes of the first part of s equal to substr under
+// Unicode case folding, or -1, -1 if there is none. Unlike searching the
+// lowercased strings, the bytes are those of s even where the cases of a
+// letter differ in length.
+func indexFold(s, substr string) (int, int) {
+	for start := range s {
+		end, rest := start, substr
+		for rest != "" && end < len(s) {
+			r, n := utf8.DecodeRuneInString(s[end:])
+			q, qn := utf8.DecodeRuneInString(rest)
+			if !strings.EqualFold(string(r), string(q)) {
+				break
+			}
+			end, rest = end+n, rest[qn:]
+		}
+		if rest == "" {
+			return start, end
+		}
+	}
+	return -1, -1
+}
+
+// openSearchResult opens the menus down to the item at path and selects it.
+func (m *Model) openSearchResult(path []int) {
+	menu := m
+	for depth, i := range path {
+		if menu.order != nil {
+			// Sorted or grouped, find where the item went
+			for k, j := range menu.order {
+				if j == i {
+					i = k
+					break
+				}
+			}
+		}
+		menu.Selection = i
+		if depth == len(path)-1 {
+			break
+		}
+		menu.openCurrentSelection()
+		if !menu.hasOpenSubmenu() {
+			return
+		}
+		menu = menu.SubMenuState
+	}
+	menu.scrollToSelection()
+}
+
+// searchLayer renders the query and its results below the bar like a
+// dropdown, with the part of each result matching the query styled by
+// Styles.Match.
+func (m Model) searchLayer() DropdownLayer {
+	d := m.derived()
+	query := m.SearchKey + m.search.query
+	width := lipgloss.Width(query)
+	for _, result := range m.search.results {
+		if w := lipgloss.Width(result.label); w > width {
+			width = w
+		}
+	}
+	if width < 20 {
+		width = 20
+	}
+	pad := func(line string) string {
+		return strings.Repeat(" ", width-lipgloss.Width(line))
+	}
+	rows := []string{d.dropdownItem.outer.Render(d.dropdownItem.base.Render(query + pad(query)))}
+	for i, result := range m.search.results {
+		st, match := d.dropdownItem, d.match
+		if i == m.search.index {
+			st, match = d.dropdownSelected, d.matchSelected
+		}
+		line := result.label
+		content := st.base.Render(line[:result.start]) +
+			match.Render(line[result.start:result.end]) +
+			st.inline.Render(line[result.end:]+pad(line))
+		outer := st.outer
+		if i == m.search.index {
+			outer, content = m.marked(st, content)
+		}
+		rows = append(rows, outer.Render(content))
+	}
+	return DropdownLayer{Content: m.Styles.Dropdown.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))}
+}
@@ -0,0 +1,64 @@
+package menubar
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestSearchKeyOptIn(t *testing.T) {
+	slash := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")}
+	m := New([]MenuItem{{Label: "File", SubMenu: []MenuItem{{Label: "Open"}}}})
+	m, _ = m.Update(slash)
+	if m.search != nil {
+		t.Error("expected searching to be off by default")
+	}
+
+	m.SearchKey = "/"
+	m, _ = m.Update(slash)
+	if m.search == nil {
+		t.Error("expected SearchKey to start a search")
+	}
+}
+
+func TestIndexFold(t *testing.T) {
+	tests := []struct {
+		s, substr  string
+		start, end int
+	}{
+		{"Open", "PEN", 1, 4},
+		{"ÉCOLE", "éc", 0, 3},
+		{"Save As…", "as…", 5, 10},
+		{"Save", "x", -1, -1},
+		{"Sa", "save", -1, -1},
+	}
+	for _, tt := range tests {
+		if start, end := indexFold(tt.s, tt.substr); start != tt.start || end != tt.end {
+			t.Errorf("indexFold(%q, %q) = %d, %d, want %d, %d", tt.s, tt.substr, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestSearchHighlightsMatches(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI256)
+	m := New([]MenuItem{{Label: "File", SubMenu: []MenuItem{{Label: "Save"}, {Label: "Open"}}}})
+	m.SetRenderer(r)
+	m.SearchKey = "/"
+	for _, key := range []string{"/", "P", "e", "n"} {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+	if len(m.search.results) != 1 {
+		t.Fatalf("expected one result, got %v", m.search.results)
+	}
+	if view := m.searchLayer().Content; !strings.Contains(view, m.derived().matchSelected.Render("pen")) {
+		t.Errorf("expected the match to be highlighted:\n%q", view)
+	}
+	if got := stripANSI(m.searchLayer().Content); !strings.Contains(got, "File > Open") {
+		t.Errorf("expected the result's path:\n%s", got)
+	}
+}
@@ -0,0 +1,85 @@
+package menubar
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type sheetRow struct {
+	label, shortcut string
+}
+
+// ViewShortcutSheet renders every item that has a Shortcut in a framed
+// overlay, one column per top-level menu, for a help screen bound to "?" or
+// Help > Keyboard Shortcuts. Place it with Overlay.
+func (m Model) ViewShortcutSheet() string {
+	s := m.Styles
+	plain := s.DropdownItem.Copy().UnsetPadding()
+	heading := plain.Copy().Bold(true)
+	shortcut := s.Shortcut.Copy().Inherit(plain)
+	_, right, _, left := s.DropdownItem.GetPadding()
+
+	var columns [][]string
+	height := 0
+	for _, item := range m.Items {
+		rows := m.sheetRows(item.SubMenu, nil)
+		if len(rows) == 0 {
+			continue
+		}
+		labelWidth, shortcutWidth := 0, 0
+		for _, row := range rows {
+			if w := lipgloss.Width(row.label); w > labelWidth {
+				labelWidth = w
+			}
+			if w := lipgloss.Width(row.shortcut); w > shortcutWidth {
+				shortcutWidth = w
+			}
+		}
+		width := left + labelWidth + m.ShortcutGap + shortcutWidth + right
+		lines := []string{heading.Copy().Width(width).PaddingLeft(left).Render(m.tr(item.Label))}
+		for _, row := range rows {
+			lines = append(lines,
+				plain.Copy().Width(left+labelWidth+m.ShortcutGap).PaddingLeft(left).Render(row.label)+
+					shortcut.Copy().Width(shortcutWidth).Align(m.ShortcutAlign).Render(row.shortcut)+
+					plain.Render(strings.Repeat(" ", right)))
+		}
+		columns = append(columns, lines)
+		if len(lines) > height {
+			height = len(lines)
+		}
+	}
+	if len(columns) == 0 {
+		return ""
+	}
+
+	// Pad the columns to the same height and separate them, so the background
+	// covers the whole sheet
+	var views []string
+	for i, lines := range columns {
+		blank := plain.Render(strings.Repeat(" ", lipgloss.Width(lines[0])))
+		for len(lines) < height {
+			lines = append(lines, blank)
+		}
+		if i > 0 {
+			views = append(views, plain.Render(strings.Repeat(" \n", height-1)+" "))
+		}
+		views = append(views, strings.Join(lines, "\n"))
+	}
+	return s.Dropdown.Render(lipgloss.JoinHorizontal(lipgloss.Top, views...))
+}
+
+func (m Model) sheetRows(items []MenuItem, parent []string) []sheetRow {
+	var rows []sheetRow
+	for _, item := range items {
+		if item.IsSeparator {
+			continue
+		}
+		path := append(append([]string{}, parent...), m.tr(item.Label))
+		if item.Shortcut != "" {
+			rows = append(rows, sheetRow{label: strings.Join(path, " > "), shortcut: item.Shortcut})
+		}
+		rows = append(rows, m.sheetRows(item.SubMenu, path)...)
+	}
+	return rows
+}
@@ -0,0 +1,122 @@
+package menubar
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// chordTimeoutMsg abandons a pending chord if no other key was pressed.
+type chordTimeoutMsg struct {
+	menuID string
+	seq    int
+}
+
+type shortcut struct {
+	keys []string
+	path []int // Indices from the top-level item down to the item
+}
+
+// shortcuts returns every enabled item with Keys, including those in static
+// submenus.
+func shortcuts(items []MenuItem, parent []int) []shortcut {
+	var list []shortcut
+	for i, item := range items {
+		if item.IsSeparator || item.Disabled {
+			continue
+		}
+		path := append(append([]int{}, parent...), i)
+		if item.Keys != "" && !item.hasSubMenu() {
+			list = append(list, shortcut{keys: canonicalKeys(item.Keys), path: path})
+		}
+		list = append(list, shortcuts(item.SubMenu, path)...)
+	}
+	return list
+}
+
+// HandleShortcut offers a key to the Keys of every item, activating the
+// matching item. It reports whether the key was consumed, so a host can try
+// the menu's shortcuts first and otherwise handle the key itself. Update calls
+// it for every key, and must still receive all other messages for chords to
+// time out.
+func (m *Model) HandleShortcut(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if m.isDropdown || m.editingInput() {
+		return nil, false
+	}
+	if m.ReopenKey != "" && len(m.chord) == 0 && keyName(msg) == canonicalKey(m.ReopenKey) && m.ReopenLast() {
+		return nil, true
+	}
+	return m.dispatchShortcut(keyName(msg))
+}
+
+// dispatchShortcut matches key against the Keys of every item, continuing a
+// pending chord if there is one. It reports whether the key was consumed.
+func (m *Model) dispatchShortcut(key string) (tea.Cmd, bool) {
+	seq := append(m.chord[:len(m.chord):len(m.chord)], key)
+	pending := false
+	for _, s := range shortcuts(m.Items, nil) {
+		if len(s.keys) < len(seq) || !equalKeys(s.keys[:len(seq)], seq) {
+			continue
+		}
+		if len(s.keys) == len(seq) {
+			m.chord = nil
+			return m.activateShortcut(s.path), true
+		}
+		pending = true
+	}
+
+	if pending {
+		m.chord = seq
+		m.chordSeq++
+		id, n := m.ID, m.chordSeq
+		return tea.Tick(m.ChordTimeout, func(time.Time) tea.Msg { return chordTimeoutMsg{menuID: id, seq: n} }), true
+	}
+	if len(m.chord) > 0 {
+		// Swallow the key that broke the chord
+		m.chord = nil
+		return nil, true
+	}
+	return nil, false
+}
+
+// activateShortcut activates the item at path. Items that ask for input open
+// the menus down to them first.
+func (m *Model) activateShortcut(path []int) tea.Cmd {
+	last := path[len(path)-1]
+	menu := *m
+	for _, i := range path[:len(path)-1] {
+		menu = menu.newSubMenu(menu.Items[i], menu.Items[i].SubMenu)
+	}
+	if item := menu.Items[last]; item.Confirm == "" && item.Input == nil {
+		return tea.Batch(menu.activate(last), m.flashPath(path))
+	}
+
+	m.Active = true
+	m.closeSubMenu()
+	open := m
+	for _, i := range path[:len(path)-1] {
+		open.Selection = i
+		open.openCurrentSelection()
+		open = open.SubMenuState
+	}
+	open.Selection = last
+	return open.activate(last)
+}
+
+// chordView describes the pending chord for the bar's right side.
+func (m Model) chordView() string {
+	if len(m.chord) == 0 {
+		return ""
+	}
+	return strings.Join(m.chord, " ") + " " + m.Glyphs.Ellipsis
+}
+
+func equalKeys(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
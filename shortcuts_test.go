@@ -0,0 +1,44 @@
+package menubar
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestShortcutWhileDropdownOpen(t *testing.T) {
+	saved := false
+	m := New([]MenuItem{
+		{Label: "File", SubMenu: []MenuItem{{Label: "Save", Keys: "ctrl+s", Action: func() tea.Msg { saved = true; return nil }}}},
+		{Label: "Edit", SubMenu: []MenuItem{{Label: "Undo"}}},
+		{Label: "View", SubMenu: []MenuItem{{Label: "Zoom"}}},
+	})
+	m.Open(2)
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	for _, msg := range collect(cmd) {
+		m, _ = m.Update(msg)
+	}
+	if !saved {
+		t.Error("expected ctrl+s to run Save")
+	}
+}
+
+// collect runs cmd and the commands it batches, returning their messages.
+func collect(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, collect(c)...)
+		}
+		return msgs
+	}
+	if msg == nil {
+		return nil
+	}
+	return []tea.Msg{msg}
+}
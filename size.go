@@ -0,0 +1,52 @@
+package menubar
+
+import "github.com/charmbracelet/lipgloss"
+
+// SetWidth sets the width the bar is rendered at by the views that aren't
+// given one, like View and ViewBar, laying out the spacer and right side
+// across it. Update sets it from each tea.WindowSizeMsg, less X, so it only
+// needs calling when the bar shares the row with something else.
+func (m *Model) SetWidth(width int) {
+	m.touch()
+	if width < 0 {
+		width = 0
+	}
+	m.sizedWidth = width
+}
+
+// BarHeight returns the number of rows the bar takes up, borders included,
+// for reserving space above the content.
+func (m Model) BarHeight() int {
+	return lipgloss.Height(m.Styles.Bar.Render("A"))
+}
+
+// BarWidth returns the width of the bar as last rendered at a width, or else
+// as rendered by ViewBar.
+func (m Model) BarWidth() int {
+	if _, width := m.cache.barSize(); width > 0 {
+		return width
+	}
+	return lipgloss.Width(m.renderBarContent("", 0))
+}
+
+// DropdownBounds returns the screen area of every open dropdown, and of the
+// panes shown beside them like previews and search results, positioned from X
+// and Y like ViewLayers. Dialogs and toasts aren't included.
+func (m Model) DropdownBounds() []Rect {
+	if m.isDropdown {
+		return nil
+	}
+	m.Debug = false
+	layers, offset := m.dropdownLayers()
+	top := m.Y + m.BarHeight()
+	bounds := make([]Rect, 0, len(layers))
+	for _, layer := range layers {
+		bounds = append(bounds, Rect{
+			X:      m.X + offset + layer.X,
+			Y:      top + layer.Y,
+			Width:  lipgloss.Width(layer.Content),
+			Height: lipgloss.Height(layer.Content),
+		})
+	}
+	return bounds
+}
@@ -0,0 +1,59 @@
+package menubar
+
+import (
+	"sort"
+	"strings"
+)
+
+// SortFunc orders the items of a submenu, see MenuItem.Sort. It reports
+// whether a belongs before b.
+type SortFunc func(a, b MenuItem) bool
+
+// Alphabetical sorts items by label, ignoring case.
+func Alphabetical(a, b MenuItem) bool {
+	return strings.ToLower(a.Label) < strings.ToLower(b.Label)
+}
+
+// Recent sorts the items activated most recently first, matching them to the
+// history's entries by label. Items never activated keep their order after
+// them.
+func (h *History) Recent() SortFunc {
+	return func(a, b MenuItem) bool {
+		ra, rb := h.rank(a.Label), h.rank(b.Label)
+		return ra != -1 && (rb == -1 || ra < rb)
+	}
+}
+
+// rank returns the position of the latest entry for label, or -1.
+func (h *History) rank(label string) int {
+	for i, entry := range h.entries {
+		if len(entry.Path) > 0 && entry.Path[len(entry.Path)-1] == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// sortItems returns a sorted copy of items, along with the index in items of
+// each sorted item. Separators stay in place and the items between them are
+// sorted separately.
+func sortItems(items []MenuItem, less SortFunc) ([]MenuItem, []int) {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	for start := 0; start < len(items); {
+		end := start
+		for end < len(items) && !items[end].IsSeparator {
+			end++
+		}
+		run := order[start:end]
+		sort.SliceStable(run, func(i, j int) bool { return less(items[run[i]], items[run[j]]) })
+		start = end + 1
+	}
+	sorted := make([]MenuItem, len(items))
+	for i, j := range order {
+		sorted[i] = items[j]
+	}
+	return sorted, order
+}
@@ -0,0 +1,79 @@
+package menubar
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Labels may contain spans styled with escape sequences, e.g. from
+// lipgloss.Style.Render. The helpers here measure and split them by their
+// visible text, and keep the item's own style applied around the spans.
+
+func isReset(seq string) bool {
+	return seq == "\x1b[0m" || seq == "\x1b[m"
+}
+
+func stripANSI(s string) string {
+	return ansiRegex.ReplaceAllString(s, "")
+}
+
+// renderSpans renders s with style, applying it again after every reset inside
+// s so a styled span doesn't end the item's style early.
+func renderSpans(style lipgloss.Style, s string) string {
+	if !strings.Contains(s, "\x1b[") {
+		return style.Render(s)
+	}
+	var b strings.Builder
+	start := 0
+	for _, loc := range ansiRegex.FindAllStringIndex(s, -1) {
+		if isReset(s[loc[0]:loc[1]]) {
+			b.WriteString(style.Render(s[start:loc[1]]))
+			start = loc[1]
+		}
+	}
+	if start < len(s) {
+		b.WriteString(style.Render(s[start:]))
+	}
+	return b.String()
+}
+
+// openSpans returns the sequences still in effect at the end of s.
+func openSpans(s string) string {
+	var codes []string
+	for _, seq := range ansiRegex.FindAllString(s, -1) {
+		if isReset(seq) {
+			codes = codes[:0]
+			continue
+		}
+		codes = append(codes, seq)
+	}
+	return strings.Join(codes, "")
+}
+
+// cutVisible splits s around the bytes [i, j) of its visible text. Each part
+// starts with the sequences left open by the parts before it.
+func cutVisible(s string, i, j int) (string, string, string) {
+	a, b := rawIndex(s, i), rawIndex(s, j)
+	pre, hot, post := s[:a], s[a:b], s[b:]
+	hot = openSpans(pre) + hot
+	post = openSpans(s[:b]) + post
+	return pre, hot, post
+}
+
+// rawIndex returns the index in s of byte n of its visible text.
+func rawIndex(s string, n int) int {
+	i := 0
+	for i < len(s) {
+		if loc := ansiRegex.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			i += loc[1]
+			continue
+		}
+		if n == 0 {
+			break
+		}
+		n--
+		i++
+	}
+	return i
+}
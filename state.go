@@ -0,0 +1,164 @@
+package menubar
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ModelState is a JSON-serializable snapshot of the user-visible menu state,
+// used to restore the menu as the user left it across sessions.
+type ModelState struct {
+	Active   bool       `json:"active"`
+	Open     []string   `json:"open,omitempty"`     // Labels of the open submenus, outermost first
+	Selected string     `json:"selected,omitempty"` // Label of the selected item in the innermost menu
+	Checked  [][]string `json:"checked,omitempty"`  // Label paths of every checked item
+
+	History []HistoryEntry `json:"history,omitempty"` // Entries of Model.History, most recent first
+}
+
+func (m Model) State() ModelState {
+	s := ModelState{Active: m.Active}
+
+	menu := &m
+	for menu.hasOpenSubmenu() {
+		s.Open = append(s.Open, menu.Items[menu.OpenSubMenu].Label)
+		menu = menu.SubMenuState
+	}
+	if menu.Selection >= 0 && menu.Selection < len(menu.Items) {
+		s.Selected = menu.Items[menu.Selection].Label
+	}
+
+	s.Checked = checkedPaths(m.Items, nil)
+	if m.History != nil {
+		s.History = m.History.Entries()
+	}
+	return s
+}
+
+// RestoreState applies a snapshot taken with State. Labels that no longer
+// exist are ignored. The returned command carries anything emitted by the
+// OnToggle callbacks of items whose checked state changed. History is only
+// restored when Model.History is set, its entries taking the Data and action of
+// the items at their paths, or shown disabled if those are gone.
+func (m *Model) RestoreState(s ModelState) tea.Cmd {
+	m.touch()
+	checked := make(map[string]bool, len(s.Checked))
+	for _, path := range s.Checked {
+		checked[pathKey(path)] = true
+	}
+	m.changedItems()
+	cmd := restoreChecked(m.Items, nil, checked)
+
+	if m.History != nil {
+		m.restoreHistory(s.History)
+	}
+
+	m.Active = s.Active
+	m.closeSubMenu()
+	m.openLabels(s.Open, s.Selected)
+
+	return cmd
+}
+
+// openLabels opens the submenus with the labels in open, outermost first, and
+// selects the item labelled selected in the innermost one. Labels that no
+// longer exist end the walk.
+func (m *Model) openLabels(open []string, selected string) {
+	menu := m
+	for _, label := range open {
+		i := menu.indexOfLabel(label)
+		if i == -1 {
+			break
+		}
+		menu.Selection = i
+		menu.openCurrentSelection()
+		if !menu.hasOpenSubmenu() {
+			break
+		}
+		menu = menu.SubMenuState
+	}
+	if i := menu.indexOfLabel(selected); i != -1 {
+		menu.Selection = i
+	}
+	menu.ensureValidSelection()
+	menu.scrollToSelection()
+}
+
+func (m Model) indexOfLabel(label string) int {
+	return labelIndex(m.Items, label)
+}
+
+// labelIndex returns the index of the item of items labeled label, or -1.
+func labelIndex(items []MenuItem, label string) int {
+	for i, item := range items {
+		if !item.IsSeparator && item.Label == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// itemAt returns the item at path, the labels leading to it through static
+// submenus, or nil.
+func itemAt(items []MenuItem, path []string) *MenuItem {
+	for i, label := range path {
+		j := labelIndex(items, label)
+		if j == -1 {
+			return nil
+		}
+		if i == len(path)-1 {
+			return &items[j]
+		}
+		items = items[j].SubMenu
+	}
+	return nil
+}
+
+// restoreHistory replaces the entries of m.History with entries, rebinding each
+// to the item still at its path.
+func (m *Model) restoreHistory(entries []HistoryEntry) {
+	m.History.Clear()
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		entry.Data, entry.action = nil, nil
+		if item := itemAt(m.Items, entry.Path); item != nil {
+			entry.Data, entry.action = item.Data, m.itemAction(entry.Path, *item)
+		}
+		m.History.add(entry)
+	}
+}
+
+func checkedPaths(items []MenuItem, parent []string) [][]string {
+	var paths [][]string
+	for _, item := range items {
+		path := append(append([]string{}, parent...), item.Label)
+		if item.Checked {
+			paths = append(paths, path)
+		}
+		paths = append(paths, checkedPaths(item.SubMenu, path)...)
+	}
+	return paths
+}
+
+func restoreChecked(items []MenuItem, parent []string, checked map[string]bool) tea.Cmd {
+	var cmds []tea.Cmd
+	for i := range items {
+		item := &items[i]
+		path := append(append([]string{}, parent...), item.Label)
+		if item.Checkable || item.RadioGroup != "" {
+			if want := checked[pathKey(path)]; want != item.Checked {
+				item.Checked = want
+				if item.OnToggle != nil {
+					cmds = append(cmds, item.OnToggle(want))
+				}
+			}
+		}
+		cmds = append(cmds, restoreChecked(item.SubMenu, path, checked))
+	}
+	return tea.Batch(cmds...)
+}
+
+func pathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
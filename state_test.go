@@ -0,0 +1,71 @@
+package menubar
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type reopenedMsg struct{}
+
+func stateModel() Model {
+	m := New([]MenuItem{
+		{Label: "File", SubMenu: []MenuItem{
+			{Label: "Reopen", Data: 7, Action: func() tea.Msg { return reopenedMsg{} }},
+			{Label: "Recent", SubMenu: []MenuItem{{Label: "notes.txt"}}},
+		}},
+		{Label: "View", SubMenu: []MenuItem{{Label: "Wrap", Checkable: true}}},
+	})
+	m.History = NewHistory(5)
+	return m
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	m := stateModel()
+	m.Open(0)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Open(1)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Open(0)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	data, err := json.Marshal(m.State())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s ModelState
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	restored := stateModel()
+	restored.RestoreState(s)
+
+	want, got := m.State(), restored.State()
+	if !reflect.DeepEqual(got.Open, want.Open) || got.Selected != want.Selected || !reflect.DeepEqual(got.Checked, want.Checked) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if len(got.History) != 2 {
+		t.Fatalf("expected 2 history entries, got %+v", got.History)
+	}
+	for i, entry := range got.History {
+		if !reflect.DeepEqual(entry.Path, want.History[i].Path) || !entry.Time.Equal(want.History[i].Time) {
+			t.Errorf("entry %d: expected %v at %v, got %v at %v", i, want.History[i].Path, want.History[i].Time, entry.Path, entry.Time)
+		}
+	}
+	reopen := got.History[1]
+	if reopen.Data != 7 || reopen.Invoke() == nil || reopen.Invoke()() != (reopenedMsg{}) {
+		t.Errorf("expected File > Reopen to be invoked again with its data, got %+v", reopen)
+	}
+}
+
+func TestStateRestoresHistoryOfRemovedItems(t *testing.T) {
+	s := ModelState{History: []HistoryEntry{{Path: []string{"File", "Gone"}}}}
+	m := stateModel()
+	m.RestoreState(s)
+	if items := m.History.Items(); len(items) != 1 || !items[0].Disabled {
+		t.Errorf("expected the removed item's entry shown disabled, got %+v", items)
+	}
+}
@@ -0,0 +1,126 @@
+package menubar
+
+import "github.com/charmbracelet/lipgloss"
+
+// itemStyles are the styles for one state of an item (normal, selected,
+// disabled), derived from Styles once instead of on every frame.
+type itemStyles struct {
+	outer       lipgloss.Style // Padded style wrapping the whole item
+	base        lipgloss.Style // outer without padding, for the parts of the item
+	inline      lipgloss.Style // base rendered inline, for the text after the hotkey
+	hotkey      lipgloss.Style
+	shortcut    lipgloss.Style
+	description lipgloss.Style
+}
+
+func newItemStyles(outer, hotkey, shortcut, description lipgloss.Style) itemStyles {
+	base := outer.Copy().UnsetPadding()
+	return itemStyles{
+		outer:       outer,
+		base:        base,
+		inline:      base.Copy().Inline(true),
+		hotkey:      hotkey.Copy().Inherit(base),
+		shortcut:    shortcut.Copy().Inherit(base),
+		description: description.Copy().Inherit(base),
+	}
+}
+
+type derivedStyles struct {
+	barItem             itemStyles
+	barSelected         itemStyles
+	barDisabled         itemStyles
+	barDisabledSelected itemStyles
+	barError            itemStyles
+	barUnfocused        itemStyles
+	barSeparator        lipgloss.Style
+	barSpacer           lipgloss.Style
+	barRight            lipgloss.Style
+	barMessage          lipgloss.Style
+
+	dropdownItem     itemStyles
+	dropdownSelected itemStyles
+	dropdownDisabled itemStyles
+	dropdownHeader   itemStyles
+
+	match, matchSelected lipgloss.Style // Matches in search results, see searchLayer
+
+	toasts [4]lipgloss.Style // By ToastLevel
+}
+
+func deriveStyles(s Styles) derivedStyles {
+	disabled := func(style lipgloss.Style) lipgloss.Style {
+		return s.Disabled.Copy().Inherit(style)
+	}
+	toast := func(level lipgloss.Style) lipgloss.Style {
+		top, right, bottom, left := s.Toast.GetPadding()
+		return level.Copy().Inherit(s.Toast).Padding(top, right, bottom, left)
+	}
+	unfocused := s.Unfocused.Copy().Inherit(s.Item).Padding(s.Item.GetPadding())
+	fill := s.Bar.Copy().UnsetPadding().BorderTop(false).BorderRight(false).BorderBottom(false).BorderLeft(false).Margin(0)
+	return derivedStyles{
+		barItem:             newItemStyles(s.Item, s.Hotkey, s.Shortcut, s.Description),
+		barSelected:         newItemStyles(s.SelectedItem, s.Hotkey, s.Shortcut, s.Description),
+		barDisabled:         newItemStyles(disabled(s.Item), s.Hotkey, s.Shortcut, s.Description),
+		barDisabledSelected: newItemStyles(disabled(s.SelectedItem), s.Hotkey, s.Shortcut, s.Description),
+		barError:            newItemStyles(s.Error.Copy().Inherit(s.Item).Padding(s.Item.GetPadding()), s.Hotkey, s.Shortcut, s.Description),
+		barUnfocused:        newItemStyles(unfocused, s.Unfocused, s.Unfocused, s.Unfocused),
+		barSeparator:        s.BarSeparator.Copy().Inherit(disabled(s.Item)),
+		barSpacer:           s.Spacer.Copy().Inherit(fill),
+		barRight:            s.Right.Copy().Inherit(fill),
+		barMessage:          s.Message.Copy().Inherit(fill),
+
+		dropdownItem:     newItemStyles(s.DropdownItem, s.Hotkey, s.Shortcut, s.Description),
+		dropdownSelected: newItemStyles(s.DropdownSelected, s.Hotkey, s.ShortcutSelected, s.Description),
+		dropdownDisabled: newItemStyles(disabled(s.DropdownItem), s.Hotkey, s.Disabled.Copy().Padding(0), s.Description),
+		dropdownHeader:   newItemStyles(s.GroupHeader.Copy().Inherit(s.DropdownItem), s.Hotkey, s.Shortcut, s.Description),
+
+		match:         s.Match.Copy().Inherit(s.DropdownItem.Copy().UnsetPadding()),
+		matchSelected: s.Match.Copy().Inherit(s.DropdownSelected.Copy().UnsetPadding()),
+
+		toasts: [4]lipgloss.Style{s.Toast, toast(s.ToastSuccess), toast(s.ToastWarning), toast(s.ToastError)},
+	}
+}
+
+// SetStyles replaces the styles and precomputes the styles derived from them,
+// so the first frame rendered with them doesn't pay for it.
+func (m *Model) SetStyles(s Styles) {
+	m.touch()
+	if m.renderer != nil {
+		s = bindStyles(s, m.renderer)
+	}
+	m.Styles = s
+	m.derived()
+}
+
+// derived returns the styles derived from m.Styles, recomputing them only
+// when m.Styles renders differently.
+func (m Model) derived() *derivedStyles {
+	if m.cache == nil {
+		d := deriveStyles(m.Styles)
+		return &d
+	}
+	if sum := m.stylesSum(); m.cache.derived == nil || m.cache.derivedSum != sum {
+		d := deriveStyles(m.Styles)
+		m.cache.derived, m.cache.derivedSum = &d, sum
+	}
+	return m.cache.derived
+}
+
+// barItemStyles returns the styles for bar item i in its current state.
+func (m Model) barItemStyles(i int) itemStyles {
+	d := m.derived()
+	selected := m.Active && i == m.Selection || m.flashed(i)
+	switch {
+	case m.blurred:
+		return d.barUnfocused
+	case m.failed != "" && m.Items[i].Label == m.failed:
+		return d.barError
+	case m.Items[i].Disabled && selected:
+		return d.barDisabledSelected
+	case m.Items[i].Disabled:
+		return d.barDisabled
+	case selected:
+		return d.barSelected
+	}
+	return d.barItem
+}
@@ -0,0 +1,59 @@
+package menubar
+
+import tea "github.com/charmbracelet/bubbletea"
+
+func (item MenuItem) hasTag(tag string) bool {
+	for _, t := range item.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// EnableTag enables or disables every item tagged with tag, in every context.
+func (m *Model) EnableTag(tag string, enabled bool) {
+	m.touch()
+	m.changedItems()
+	m.eachItem(func(item *MenuItem) {
+		if item.hasTag(tag) {
+			item.Disabled = !enabled
+		}
+	})
+}
+
+// CheckTag checks or unchecks every item tagged with tag, in every context.
+// The returned command carries anything emitted by the OnToggle callbacks of
+// items whose checked state changed.
+func (m *Model) CheckTag(tag string, checked bool) tea.Cmd {
+	m.touch()
+	m.changedItems()
+	var cmds []tea.Cmd
+	m.eachItem(func(item *MenuItem) {
+		if item.hasTag(tag) && (item.Checked != checked || item.Mixed) {
+			item.Checked, item.Mixed = checked, false
+			if item.OnToggle != nil {
+				cmds = append(cmds, item.OnToggle(checked))
+			}
+		}
+	})
+	return tea.Batch(cmds...)
+}
+
+// eachItem calls fn with every item of every context, including those in
+// static submenus.
+func (m *Model) eachItem(fn func(*MenuItem)) {
+	walkItems(m.Items, fn)
+	for name, c := range m.contexts {
+		if name != m.context {
+			walkItems(c.items, fn)
+		}
+	}
+}
+
+func walkItems(items []MenuItem, fn func(*MenuItem)) {
+	for i := range items {
+		fn(&items[i])
+		walkItems(items[i].SubMenu, fn)
+	}
+}
@@ -0,0 +1,83 @@
+package menubar
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// FileMenuConfig wires StandardFileMenu items to the application. Items whose
+// callback is nil are shown disabled, except Quit which defaults to tea.Quit.
+type FileMenuConfig struct {
+	New    func() tea.Msg
+	Open   func() tea.Msg
+	Save   func() tea.Msg
+	SaveAs func() tea.Msg
+	Close  func() tea.Msg
+	Quit   func() tea.Msg
+}
+
+// EditMenuConfig wires StandardEditMenu items to the application. Items whose
+// callback is nil are shown disabled.
+type EditMenuConfig struct {
+	Undo      func() tea.Msg
+	Redo      func() tea.Msg
+	Cut       func() tea.Msg
+	Copy      func() tea.Msg
+	Paste     func() tea.Msg
+	SelectAll func() tea.Msg
+	Find      func() tea.Msg
+}
+
+// AboutMsg is sent by the About item of StandardHelpMenu.
+type AboutMsg struct {
+	AppName string
+	Version string
+}
+
+func StandardFileMenu(cfg FileMenuConfig) []MenuItem {
+	quit := cfg.Quit
+	if quit == nil {
+		quit = tea.Quit
+	}
+	return []MenuItem{
+		standardItem("New", "N", "⌃+N", cfg.New),
+		standardItem("Open...", "O", "⌃+O", cfg.Open),
+		Separator(),
+		standardItem("Save", "S", "⌃+S", cfg.Save),
+		standardItem("Save As...", "A", "", cfg.SaveAs),
+		Separator(),
+		standardItem("Close", "C", "⌃+W", cfg.Close),
+		standardItem("Exit", "x", "⌃+Q", quit),
+	}
+}
+
+func StandardEditMenu(cfg EditMenuConfig) []MenuItem {
+	return []MenuItem{
+		standardItem("Undo", "U", "⌃+Z", cfg.Undo),
+		standardItem("Redo", "R", "⌃+Y", cfg.Redo),
+		Separator(),
+		standardItem("Cut", "t", "⌃+X", cfg.Cut),
+		standardItem("Copy", "C", "⌃+C", cfg.Copy),
+		standardItem("Paste", "P", "⌃+V", cfg.Paste),
+		standardItem("Select All", "A", "⌃+A", cfg.SelectAll),
+		Separator(),
+		standardItem("Find...", "F", "⌃+F", cfg.Find),
+	}
+}
+
+func StandardHelpMenu(appName, version string) []MenuItem {
+	return []MenuItem{
+		{
+			Label:  "About " + appName,
+			Hotkey: "A",
+			Action: func() tea.Msg { return AboutMsg{AppName: appName, Version: version} },
+		},
+	}
+}
+
+func standardItem(label, hotkey, shortcut string, action func() tea.Msg) MenuItem {
+	return MenuItem{
+		Label:    label,
+		Hotkey:   hotkey,
+		Shortcut: shortcut,
+		Action:   action,
+		Disabled: action == nil,
+	}
+}
@@ -0,0 +1,64 @@
+package menubar
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStandardMenus(t *testing.T) {
+	type savedMsg struct{}
+	save := func() tea.Msg { return savedMsg{} }
+	tests := []struct {
+		name     string
+		items    []MenuItem
+		enabled  string
+		disabled string
+	}{
+		{"file", StandardFileMenu(FileMenuConfig{Save: save}), "Save", "Open..."},
+		{"edit", StandardEditMenu(EditMenuConfig{Copy: save}), "Copy", "Paste"},
+	}
+	for _, tt := range tests {
+		hotkeys := map[string]string{}
+		for _, item := range tt.items {
+			if item.IsSeparator {
+				continue
+			}
+			if !strings.Contains(item.Label, item.Hotkey) {
+				t.Errorf("%s: hotkey %q isn't in %q", tt.name, item.Hotkey, item.Label)
+			}
+			key := strings.ToLower(item.Hotkey)
+			if other, ok := hotkeys[key]; ok {
+				t.Errorf("%s: %q and %q share the hotkey %q", tt.name, other, item.Label, key)
+			}
+			hotkeys[key] = item.Label
+		}
+
+		enabled := tt.items[labelIndex(tt.items, tt.enabled)]
+		if enabled.Disabled || enabled.Action == nil || enabled.Action() != (savedMsg{}) {
+			t.Errorf("%s: expected %s to run its callback", tt.name, tt.enabled)
+		}
+		if disabled := tt.items[labelIndex(tt.items, tt.disabled)]; !disabled.Disabled {
+			t.Errorf("%s: expected %s without a callback to be disabled", tt.name, tt.disabled)
+		}
+	}
+}
+
+func TestStandardFileMenuQuits(t *testing.T) {
+	items := StandardFileMenu(FileMenuConfig{})
+	exit := items[labelIndex(items, "Exit")]
+	if exit.Disabled || exit.Action == nil || exit.Action() != (tea.QuitMsg{}) {
+		t.Errorf("expected Exit to quit by default, got %+v", exit)
+	}
+}
+
+func TestStandardHelpMenu(t *testing.T) {
+	items := StandardHelpMenu("Notes", "1.2.0")
+	if len(items) != 1 || items[0].Label != "About Notes" {
+		t.Fatalf("expected an About Notes item, got %+v", items)
+	}
+	if msg := items[0].Action(); msg != (AboutMsg{AppName: "Notes", Version: "1.2.0"}) {
+		t.Errorf("expected an AboutMsg, got %#v", msg)
+	}
+}
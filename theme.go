@@ -0,0 +1,225 @@
+package menubar
+
+import (
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// HighContrastStyles returns a black and white theme with a bright selection
+// color, for low-vision users and washed out displays.
+func HighContrastStyles() Styles {
+	black, white, yellow := lipgloss.Color("#000000"), lipgloss.Color("#FFFFFF"), lipgloss.Color("#FFFF00")
+	return Styles{
+		Bar: lipgloss.NewStyle().
+			Background(black).
+			Foreground(white),
+		Item: lipgloss.NewStyle().
+			Padding(0, 1).
+			Background(black).
+			Foreground(white),
+		SelectedItem: lipgloss.NewStyle().
+			Padding(0, 1).
+			Bold(true).
+			Background(yellow).
+			Foreground(black),
+		Shortcut: lipgloss.NewStyle().
+			Foreground(white),
+		Dropdown: lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			BorderBackground(black).
+			BorderForeground(white),
+		DropdownItem: lipgloss.NewStyle().
+			Padding(0, 1).
+			Background(black).
+			Foreground(white),
+		DropdownSelected: lipgloss.NewStyle().
+			Padding(0, 1).
+			Bold(true).
+			Background(yellow).
+			Foreground(black),
+		ShortcutSelected: lipgloss.NewStyle().
+			Foreground(black),
+		Hotkey: lipgloss.NewStyle().
+			Underline(true).
+			Bold(true),
+		Separator: lipgloss.NewStyle().
+			Padding(0, 1).
+			Background(black).
+			Foreground(white),
+		Disabled: lipgloss.NewStyle().
+			Padding(0, 1).
+			Italic(true).
+			Foreground(lipgloss.Color("#A0A0A0")),
+		Error: lipgloss.NewStyle().
+			Bold(true).
+			Background(lipgloss.Color("#FF0000")).
+			Foreground(white),
+		Unfocused: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#A0A0A0")),
+		Message: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(yellow),
+		Description: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#A0A0A0")),
+		ScrollIndicator: lipgloss.NewStyle().
+			Background(black).
+			Foreground(white),
+		GroupHeader: lipgloss.NewStyle().
+			Padding(0, 1).
+			Bold(true).
+			Underline(true).
+			Background(black).
+			Foreground(white),
+		Match: lipgloss.NewStyle().
+			Bold(true).
+			Underline(true),
+		Preview: lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			BorderBackground(black).
+			BorderForeground(white).
+			Padding(0, 1).
+			Background(black).
+			Foreground(white),
+		Toast: lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			BorderBackground(black).
+			BorderForeground(white).
+			Padding(0, 1).
+			Background(black).
+			Foreground(white),
+		ToastSuccess: lipgloss.NewStyle().
+			BorderForeground(lipgloss.Color("#00FF00")),
+		ToastWarning: lipgloss.NewStyle().
+			BorderForeground(yellow),
+		ToastError: lipgloss.NewStyle().
+			Bold(true).
+			BorderForeground(lipgloss.Color("#FF0000")),
+	}
+}
+
+// MonochromeStyles returns a theme that uses no colors at all, telling states
+// apart with reverse video, bold, underline and faint text instead.
+func MonochromeStyles() Styles {
+	return Styles{
+		Bar: lipgloss.NewStyle(),
+		Item: lipgloss.NewStyle().
+			Padding(0, 1),
+		SelectedItem: lipgloss.NewStyle().
+			Padding(0, 1).
+			Bold(true).
+			Reverse(true),
+		Shortcut: lipgloss.NewStyle(),
+		Dropdown: lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()),
+		DropdownItem: lipgloss.NewStyle().
+			Padding(0, 1),
+		DropdownSelected: lipgloss.NewStyle().
+			Padding(0, 1).
+			Reverse(true),
+		ShortcutSelected: lipgloss.NewStyle(),
+		Hotkey: lipgloss.NewStyle().
+			Underline(true),
+		Separator: lipgloss.NewStyle().
+			Padding(0, 1),
+		Disabled: lipgloss.NewStyle().
+			Padding(0, 1).
+			Faint(true),
+		Error: lipgloss.NewStyle().
+			Italic(true).
+			Reverse(true),
+		Unfocused: lipgloss.NewStyle().
+			Faint(true),
+		Message: lipgloss.NewStyle().
+			Bold(true),
+		Description: lipgloss.NewStyle().
+			Faint(true),
+		ScrollIndicator: lipgloss.NewStyle().
+			Faint(true),
+		GroupHeader: lipgloss.NewStyle().
+			Padding(0, 1).
+			Bold(true),
+		Match: lipgloss.NewStyle().
+			Bold(true).
+			Underline(true),
+		Preview: lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			Padding(0, 1),
+		Toast: lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			Padding(0, 1),
+		ToastSuccess: lipgloss.NewStyle(),
+		ToastWarning: lipgloss.NewStyle().
+			Bold(true),
+		ToastError: lipgloss.NewStyle().
+			Bold(true).
+			Reverse(true),
+	}
+}
+
+// ASCIIBorder is a border drawn with plain ASCII characters.
+var ASCIIBorder = lipgloss.Border{
+	Top:         "-",
+	Bottom:      "-",
+	Left:        "|",
+	Right:       "|",
+	TopLeft:     "+",
+	TopRight:    "+",
+	BottomLeft:  "+",
+	BottomRight: "+",
+}
+
+// PlainStyles returns styles that never emit ANSI escape sequences and draw
+// dropdowns with ASCII borders. The output is stable plain text, suitable for
+// golden file tests and dumb terminals. Set them with UsePlain, which also
+// swaps in ASCII glyphs and marks the selection without colors.
+func PlainStyles() Styles {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.Ascii)
+	return Styles{
+		Bar: r.NewStyle(),
+		Item: r.NewStyle().
+			Padding(0, 1),
+		SelectedItem: r.NewStyle().
+			Padding(0, 1),
+		Shortcut: r.NewStyle(),
+		Dropdown: r.NewStyle().
+			Border(ASCIIBorder),
+		DropdownItem: r.NewStyle().
+			Padding(0, 1),
+		DropdownSelected: r.NewStyle().
+			Padding(0, 1),
+		ShortcutSelected: r.NewStyle(),
+		Hotkey:           r.NewStyle(),
+		Separator: r.NewStyle().
+			Padding(0, 1),
+		Disabled: r.NewStyle().
+			Padding(0, 1),
+		Error:           r.NewStyle(),
+		Unfocused:       r.NewStyle(),
+		Message:         r.NewStyle(),
+		Description:     r.NewStyle(),
+		ScrollIndicator: r.NewStyle(),
+		GroupHeader: r.NewStyle().
+			Padding(0, 1),
+		Match: r.NewStyle(),
+		Preview: r.NewStyle().
+			Border(ASCIIBorder).
+			Padding(0, 1),
+		Toast: r.NewStyle().
+			Border(ASCIIBorder).
+			Padding(0, 1),
+		ToastSuccess: r.NewStyle(),
+		ToastWarning: r.NewStyle(),
+		ToastError:   r.NewStyle(),
+	}
+}
+
+func noColor() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return true
+	}
+	return lipgloss.ColorProfile() == termenv.Ascii
+}
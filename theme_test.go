@@ -0,0 +1,40 @@
+package menubar
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestUsePlain(t *testing.T) {
+	m := New([]MenuItem{
+		{Label: "File", Modified: true, SubMenu: []MenuItem{
+			{Label: "Same"},
+			{Label: "Same"},
+			{IsSeparator: true},
+			{Label: "Wrap", Checkable: true, Checked: true},
+			{Label: "Mixed", Checkable: true, Mixed: true},
+			{Label: "Recent", SubMenu: []MenuItem{{Label: "notes.txt"}}},
+		}},
+		{IsSeparator: true},
+		{Label: "Edit"},
+	})
+	m.UsePlain()
+	m.MaxVisibleItems = 4
+	m.Open(0)
+
+	view := m.View()
+	for i, r := range view {
+		if r >= utf8.RuneSelf || r == '\x1b' {
+			t.Fatalf("expected pure ASCII, got %q at %d:\n%s", r, i, view)
+		}
+	}
+	rows := strings.Split(view, "\n")
+	// Below the bar, the top border and the scroll indicator
+	if rows[3] == rows[4] {
+		t.Errorf("expected the selected row to differ from the same item below it:\n%s", view)
+	}
+	if !strings.HasPrefix(rows[0], ">") {
+		t.Errorf("expected the open menu to be marked in the bar:\n%s", view)
+	}
+}
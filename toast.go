@@ -0,0 +1,125 @@
+package menubar
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxToasts is how many toasts are shown at once, the oldest going first.
+const maxToasts = 5
+
+// ToastLevel picks the style of a toast.
+type ToastLevel int
+
+const (
+	ToastInfo    ToastLevel = iota // Styled with Styles.Toast
+	ToastSuccess                   // Styled with Styles.ToastSuccess
+	ToastWarning                   // Styled with Styles.ToastWarning
+	ToastError                     // Styled with Styles.ToastError
+)
+
+// Corner is a corner of the screen, see Model.ToastCorner.
+type Corner int
+
+const (
+	BottomRight Corner = iota
+	BottomLeft
+	TopRight
+	TopLeft
+)
+
+type toast struct {
+	id    int
+	text  string
+	level ToastLevel
+}
+
+// toastMsg shows a toast, see Toast.
+type toastMsg struct {
+	text     string
+	level    ToastLevel
+	duration time.Duration
+}
+
+// toastExpiredMsg removes the toast with id.
+type toastExpiredMsg struct {
+	menuID string
+	id     int
+}
+
+// Toast returns a command that shows text in a toast for the duration d,
+// stacked with any others in the ToastCorner of the screen. It is shown by
+// every menubar the message reaches, so return it from actions to report how
+// they went.
+func Toast(text string, level ToastLevel, d time.Duration) tea.Cmd {
+	return func() tea.Msg { return toastMsg{text: text, level: level, duration: d} }
+}
+
+func (m *Model) addToast(msg toastMsg) tea.Cmd {
+	m.toastSeq++
+	toasts := append(m.toasts[:len(m.toasts):len(m.toasts)], toast{id: m.toastSeq, text: msg.text, level: msg.level})
+	if len(toasts) > maxToasts {
+		toasts = toasts[len(toasts)-maxToasts:]
+	}
+	m.toasts = toasts
+	id, n := m.ID, m.toastSeq
+	return tea.Tick(msg.duration, func(time.Time) tea.Msg { return toastExpiredMsg{menuID: id, id: n} })
+}
+
+func (m *Model) removeToast(id int) {
+	toasts := make([]toast, 0, len(m.toasts))
+	for _, t := range m.toasts {
+		if t.id != id {
+			toasts = append(toasts, t)
+		}
+	}
+	m.toasts = toasts
+}
+
+// toastLayers returns the toasts stacked in the ToastCorner of the screen, as
+// last sized by a tea.WindowSizeMsg, or of a bar of the given width below it
+// until one arrives. The newest toast is the one nearest the corner's edge.
+func (m Model) toastLayers(width int) []DropdownLayer {
+	if len(m.toasts) == 0 {
+		return nil
+	}
+	if m.screen.Width > 0 {
+		width = m.screen.Width
+	}
+	styles := m.derived().toasts
+	views := make([]string, len(m.toasts))
+	height := 0
+	for i, t := range m.toasts {
+		style := styles[ToastInfo]
+		if t.level >= 0 && int(t.level) < len(styles) {
+			style = styles[t.level]
+		}
+		views[i] = style.Render(t.text)
+		height += lipgloss.Height(views[i])
+	}
+
+	y := m.Y + m.BarHeight()
+	bottom := m.ToastCorner == BottomRight || m.ToastCorner == BottomLeft
+	if bottom && m.screen.Height > 0 {
+		y = m.screen.Height - height
+	}
+	layers := make([]DropdownLayer, len(views))
+	for i, view := range views {
+		if !bottom {
+			// Newest first, right below the bar
+			view = views[len(views)-1-i]
+		}
+		x := 0
+		if m.ToastCorner == BottomRight || m.ToastCorner == TopRight {
+			x = width - lipgloss.Width(view)
+		}
+		if x < 0 {
+			x = 0
+		}
+		layers[i] = DropdownLayer{Content: view, X: x, Y: y}
+		y += lipgloss.Height(view)
+	}
+	return layers
+}
@@ -0,0 +1,43 @@
+package menubar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func toasted(text string, level ToastLevel) Model {
+	m := New([]MenuItem{{Label: "File", SubMenu: []MenuItem{{Label: "Open"}}}})
+	m.UsePlain()
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+	m, _ = m.Update(Toast(text, level, time.Second)())
+	return m
+}
+
+func TestToastUnknownLevel(t *testing.T) {
+	for _, level := range []ToastLevel{-1, ToastError + 1} {
+		m := toasted("Synced", level)
+		if !strings.Contains(m.View(), "Synced") {
+			t.Errorf("level %d: expected the toast in the view:\n%s", level, m.View())
+		}
+	}
+}
+
+func TestToastInEveryView(t *testing.T) {
+	m := toasted("Synced", ToastInfo)
+	if !strings.Contains(m.View(), "Synced") {
+		t.Errorf("expected the toast in View:\n%s", m.View())
+	}
+
+	layers := m.ViewLayers("", 40)
+	want := layers[len(layers)-1]
+	m.Open(0)
+	dropdowns, offset := m.ViewDropdownLayers()
+	got := dropdowns[len(dropdowns)-1]
+	if got.Content != want.Content || m.X+offset+got.X != want.X || m.Y+m.BarHeight()+got.Y != want.Y {
+		t.Errorf("expected the toast of ViewLayers at %d,%d in ViewDropdownLayers, got %q at %d,%d",
+			want.X, want.Y, got.Content, m.X+offset+got.X, m.Y+m.BarHeight()+got.Y)
+	}
+}
@@ -0,0 +1,52 @@
+package menubar
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HyperlinkMsg is sent by a URLItem that couldn't open a browser, for example
+// over SSH. Link is the URL as an OSC 8 hyperlink, which the host can show so
+// the user can open it from their own terminal.
+type HyperlinkMsg struct {
+	URL  string
+	Link string
+	Err  error // Why no browser was opened, nil over SSH
+}
+
+// URLItem returns an item that opens url in the system browser.
+func URLItem(label, url string) MenuItem {
+	return MenuItem{Label: label, Action: openURL(url)}
+}
+
+func openURL(url string) func() tea.Msg {
+	return func() tea.Msg {
+		fallback := HyperlinkMsg{URL: url, Link: hyperlink(url, url)}
+		if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+			return fallback
+		}
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", url)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+		default:
+			cmd = exec.Command("xdg-open", url)
+		}
+		if err := cmd.Start(); err != nil {
+			fallback.Err = err
+			return fallback
+		}
+		go cmd.Wait() // Reap the opener without holding up the program
+		return nil
+	}
+}
+
+// hyperlink wraps text in an OSC 8 hyperlink to url.
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
@@ -0,0 +1,29 @@
+package menubar
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSetValue(t *testing.T) {
+	m := New([]MenuItem{{Label: "View", SubMenu: []MenuItem{
+		{Label: "Appearance", SubMenu: []MenuItem{{Label: "Theme", Value: "Light"}}},
+	}}})
+	m.UsePlain()
+	m.Open(0)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if !strings.Contains(m.View(), "Light") {
+		t.Fatalf("expected the value:\n%s", m.View())
+	}
+
+	m.SetValue([]string{"View", "Appearance", "Theme"}, "Dracula")
+	if view := m.View(); !strings.Contains(view, "Dracula") || strings.Contains(view, "Light") {
+		t.Errorf("expected the new value:\n%s", view)
+	}
+	m.SetValue([]string{"View", "Missing"}, "Dark")
+	if !strings.Contains(m.View(), "Dracula") {
+		t.Errorf("expected a missing path to change nothing:\n%s", m.View())
+	}
+}
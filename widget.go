@@ -0,0 +1,96 @@
+package menubar
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Widget is a self-updating segment shown on the right side of the bar, after
+// the content passed to ViewBarWithRightSide. Widgets receive every message
+// passed to Update, so they can own their tick commands.
+type Widget interface {
+	Init() tea.Cmd
+	Update(msg tea.Msg) (Widget, tea.Cmd)
+	View() string
+}
+
+var lastWidgetID int64
+
+func nextWidgetID() int {
+	return int(atomic.AddInt64(&lastWidgetID, 1))
+}
+
+func (m *Model) updateWidgets(msg tea.Msg) tea.Cmd {
+	if len(m.Widgets) == 0 {
+		return nil
+	}
+	widgets := make([]Widget, len(m.Widgets))
+	cmds := make([]tea.Cmd, len(m.Widgets))
+	for i, w := range m.Widgets {
+		widgets[i], cmds[i] = w.Update(msg)
+	}
+	m.Widgets = widgets
+	return tea.Batch(cmds...)
+}
+
+func (m Model) widgetsView() string {
+	views := make([]string, len(m.Widgets))
+	for i, w := range m.Widgets {
+		views[i] = w.View()
+	}
+	return joinRight(views...)
+}
+
+// joinRight joins the non-empty segments of the bar's right side.
+func joinRight(segments ...string) string {
+	var parts []string
+	for _, s := range segments {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "  ")
+}
+
+type clockTickMsg struct {
+	id   int
+	time time.Time
+}
+
+// Clock is a Widget showing the current time.
+type Clock struct {
+	Format string // Layout passed to time.Time.Format
+
+	id  int
+	now time.Time
+}
+
+// ClockWidget returns a clock showing the time in the given layout, like
+// "15:04:05", updated every second.
+func ClockWidget(format string) Clock {
+	return Clock{Format: format, id: nextWidgetID(), now: time.Now()}
+}
+
+func (c Clock) Init() tea.Cmd {
+	return c.tick()
+}
+
+func (c Clock) Update(msg tea.Msg) (Widget, tea.Cmd) {
+	if msg, ok := msg.(clockTickMsg); ok && msg.id == c.id {
+		c.now = msg.time
+		return c, c.tick()
+	}
+	return c, nil
+}
+
+func (c Clock) View() string {
+	return c.now.Format(c.Format)
+}
+
+func (c Clock) tick() tea.Cmd {
+	id := c.id
+	return tea.Every(time.Second, func(t time.Time) tea.Msg { return clockTickMsg{id: id, time: t} })
+}
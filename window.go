@@ -0,0 +1,131 @@
+package menubar
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SwitchWindowMsg is sent when a window is picked from a WindowMenu.
+type SwitchWindowMsg struct {
+	Index int
+	Name  string
+}
+
+// CloseWindowMsg is sent when the active window is closed from a WindowMenu
+// with a CloseLabel.
+type CloseWindowMsg struct {
+	Index int
+	Name  string
+}
+
+// WindowMenu keeps track of an application's windows (or panes) and builds the
+// conventional "Window" submenu listing them.
+type WindowMenu struct {
+	Windows []string
+	Active  int // Index of the active window, -1 if none
+
+	CloseLabel string // Label of an item closing the active window, left out if empty
+}
+
+func NewWindowMenu(windows ...string) WindowMenu {
+	w := WindowMenu{Active: -1}
+	for _, name := range windows {
+		w.Register(name)
+	}
+	return w
+}
+
+// Register adds a window. The first registered window becomes active.
+func (w *WindowMenu) Register(name string) {
+	w.Windows = append(w.Windows, name)
+	if w.Active == -1 {
+		w.Active = 0
+	}
+}
+
+func (w *WindowMenu) Unregister(name string) {
+	i := w.index(name)
+	if i == -1 {
+		return
+	}
+	w.Windows = append(w.Windows[:i], w.Windows[i+1:]...)
+	if w.Active >= len(w.Windows) || w.Active > i {
+		w.Active--
+	}
+}
+
+// Sync replaces the windows with the host's own list, like the tabs of a tab
+// bar, after one was opened, closed or switched to there.
+func (w *WindowMenu) Sync(windows []string, active int) {
+	w.Windows = append(w.Windows[:0:0], windows...)
+	if active < 0 || active >= len(windows) {
+		active = -1
+	}
+	w.Active = active
+}
+
+func (w *WindowMenu) SetActive(name string) {
+	if i := w.index(name); i != -1 {
+		w.Active = i
+	}
+}
+
+func (w WindowMenu) index(name string) int {
+	for i, window := range w.Windows {
+		if window == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Items returns the submenu listing the registered windows as a radio group,
+// with the active one checked, and ⌘1…⌘9 shortcuts for the first nine.
+func (w WindowMenu) Items() []MenuItem {
+	items := make([]MenuItem, len(w.Windows))
+	for i, name := range w.Windows {
+		i, name := i, name
+		items[i] = MenuItem{
+			Label:      name,
+			RadioGroup: "windows",
+			Checked:    i == w.Active,
+			Action:     func() tea.Msg { return SwitchWindowMsg{Index: i, Name: name} },
+		}
+		if i < 9 {
+			items[i].Hotkey = fmt.Sprint(i + 1)
+			items[i].Shortcut = fmt.Sprintf("⌘%d", i+1)
+		}
+	}
+	if w.CloseLabel != "" && w.Active >= 0 && w.Active < len(w.Windows) {
+		i, name := w.Active, w.Windows[w.Active]
+		items = append(items, Separator(), MenuItem{
+			Label:  w.CloseLabel,
+			Action: func() tea.Msg { return CloseWindowMsg{Index: i, Name: name} },
+		})
+	}
+	return items
+}
+
+// SetWindowMenu makes w the submenu of the top-level item with label, keeping
+// the selection of the submenu if it is open. Call it whenever w changes so the
+// menu stays in sync with the windows.
+func (m *Model) SetWindowMenu(label string, w WindowMenu) {
+	m.touch()
+	i := m.indexOfLabel(label)
+	if i == -1 {
+		return
+	}
+	m.changedItems()
+	m.Items[i].SubMenu = w.Items()
+	if m.OpenSubMenu != i || !m.hasOpenSubmenu() {
+		return
+	}
+	selection := m.SubMenuState.Selection
+	m.Selection = i
+	m.openCurrentSelection()
+	if m.hasOpenSubmenu() {
+		m.SubMenuState.Selection = selection
+		m.SubMenuState.ensureValidSelection()
+	}
+}
@@ -0,0 +1,19 @@
+package menubar
+
+import "testing"
+
+func TestWindowMenuChecksOnlyTheChosenWindow(t *testing.T) {
+	w := WindowMenu{Windows: []string{"One", "Two", "Three"}, Active: 0}
+	m := New([]MenuItem{{Label: "Window", SubMenu: w.Items()}})
+	m.Open(0)
+
+	for _, choice := range []int{0, 2} {
+		m.SubMenuState.SetSelection(choice)
+		m.SubMenuState.activate(choice)
+		for i, item := range m.SubMenuState.Items {
+			if item.Checked != (i == choice) {
+				t.Errorf("after choosing %d, %q checked = %v", choice, item.Label, item.Checked)
+			}
+		}
+	}
+}
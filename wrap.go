@@ -0,0 +1,112 @@
+package menubar
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type wrapper struct {
+	content tea.Model
+	menu    Model
+	width   int
+}
+
+// Wrap returns a model that shows the menubar on the top row above content,
+// overlaying its dropdowns. Messages are routed to both: content is sized to
+// the rows below the bar, receives mouse events the menubar doesn't cover with
+// coordinates relative to its own view, and receives keys while the menubar
+// isn't focused, along with ctrl+c at any time, which also cancels running
+// ActionCtx actions. F10 focuses the menubar and Esc leaves it once every
+// dropdown is closed.
+func Wrap(content tea.Model, m Model) tea.Model {
+	m.X, m.Y = 0, 0
+	return wrapper{content: content, menu: m}
+}
+
+func (w wrapper) Init() tea.Cmd {
+	return tea.Batch(w.content.Init(), w.menu.Init())
+}
+
+func (w wrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var menuCmd, contentCmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		w.width = msg.Width
+		w.menu, menuCmd = w.menu.Update(msg)
+		msg.Height -= w.barHeight()
+		w.content, contentCmd = w.content.Update(msg)
+		return w, tea.Batch(menuCmd, contentCmd)
+	case tea.KeyMsg:
+		if w.menu.DialogOpen() {
+			w.menu, menuCmd = w.menu.Update(msg)
+			return w, menuCmd
+		}
+		if msg.Type == tea.KeyCtrlC {
+			w.menu.CancelActions()
+			w.content, contentCmd = w.content.Update(msg)
+			return w, contentCmd
+		}
+		if w.menu.DisableKeyboard {
+			w.content, contentCmd = w.content.Update(msg)
+			return w, contentCmd
+		}
+		if !w.menu.Active {
+			if msg.String() == "f10" {
+				w.menu.Active = true
+				return w, nil
+			}
+			if cmd, ok := w.menu.HandleShortcut(msg); ok {
+				return w, cmd
+			}
+			if cmd, ok := w.menu.openMnemonic(msg); ok {
+				return w, cmd
+			}
+			w.content, contentCmd = w.content.Update(msg)
+			return w, contentCmd
+		}
+		if msg.String() == "esc" && w.menu.OpenSubMenu == -1 && w.menu.search == nil {
+			w.menu.Active = false
+			return w, nil
+		}
+		w.menu, menuCmd = w.menu.Update(msg)
+		return w, menuCmd
+	case tea.MouseMsg:
+		covered := !w.menu.DisableMouse && w.covers(msg.X, msg.Y)
+		w.menu, menuCmd = w.menu.Update(msg)
+		if covered {
+			return w, menuCmd
+		}
+		msg.Y -= w.barHeight()
+		w.content, contentCmd = w.content.Update(msg)
+		return w, tea.Batch(menuCmd, contentCmd)
+	}
+	w.menu, menuCmd = w.menu.Update(msg)
+	w.content, contentCmd = w.content.Update(msg)
+	return w, tea.Batch(menuCmd, contentCmd)
+}
+
+func (w wrapper) View() string {
+	layers := w.menu.ViewLayers("", w.width)
+	view := lipgloss.JoinVertical(lipgloss.Left, layers[0].Content, w.content.View())
+	for _, layer := range layers[1:] {
+		view = Overlay(view, layer.Content, layer.X, layer.Y)
+	}
+	return view
+}
+
+func (w wrapper) barHeight() int {
+	return lipgloss.Height(w.menu.ViewBarWithRightSide("", w.width))
+}
+
+// covers reports whether the bar or one of its dropdowns is at the position.
+func (w wrapper) covers(x, y int) bool {
+	if y < w.barHeight() {
+		return true
+	}
+	for _, layer := range w.menu.ViewLayers("", w.width) {
+		if x >= layer.X && x < layer.X+lipgloss.Width(layer.Content) && y >= layer.Y && y < layer.Y+lipgloss.Height(layer.Content) {
+			return true
+		}
+	}
+	return false
+}